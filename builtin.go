@@ -1,9 +1,15 @@
 package validating
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"regexp"
+	"runtime"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 	"unicode/utf8"
 
 	"golang.org/x/exp/constraints"
@@ -19,6 +25,21 @@ func (f Func) Validate(field *Field) Errors {
 	return f(field)
 }
 
+// FuncContext is an adapter to allow the use of ordinary functions, which
+// need a context.Context, as validators. If f is a function with the
+// appropriate signature, FuncContext(f) is a ContextValidator that calls f.
+type FuncContext func(ctx context.Context, field *Field) Errors
+
+// Validate calls f(field.Context(), field).
+func (f FuncContext) Validate(field *Field) Errors {
+	return f(field.Context(), field)
+}
+
+// ValidateContext calls f(ctx, field).
+func (f FuncContext) ValidateContext(ctx context.Context, field *Field) Errors {
+	return f(ctx, field)
+}
+
 // Schema is a field mapping, which defines
 // the corresponding validator for each field.
 type Schema map[*Field]Validator
@@ -28,6 +49,45 @@ func (s Schema) Validate(field *Field) (errs Errors) {
 	return validateSchema(s, field, func(name string) string { return name })
 }
 
+func (s Schema) each(f func(field *Field, validator Validator) bool) {
+	for field, validator := range s {
+		if !f(field, validator) {
+			return
+		}
+	}
+}
+
+// FieldValidator is a (Field, Validator) pair, which is the element of
+// an OrderedSchema.
+type FieldValidator struct {
+	Field     *Field
+	Validator Validator
+}
+
+// OrderedSchema is a field sequence, which defines the corresponding
+// validator for each field. Unlike Schema, fields in OrderedSchema are
+// always validated in their declaration order, which makes the order
+// of the returned errors deterministic.
+type OrderedSchema []FieldValidator
+
+// Add appends the given field, along with its validator, to the schema.
+func (s OrderedSchema) Add(field *Field, validator Validator) OrderedSchema {
+	return append(s, FieldValidator{Field: field, Validator: validator})
+}
+
+// Validate validates fields per the given according to the schema.
+func (s OrderedSchema) Validate(field *Field) (errs Errors) {
+	return validateSchema(s, field, func(name string) string { return name })
+}
+
+func (s OrderedSchema) each(f func(field *Field, validator Validator) bool) {
+	for _, fv := range s {
+		if !f(fv.Field, fv.Validator) {
+			return
+		}
+	}
+}
+
 // Value is a shortcut function used to create a schema for a simple value.
 func Value(value any, validator Validator) Schema {
 	return Schema{
@@ -38,15 +98,33 @@ func Value(value any, validator Validator) Schema {
 // Nested is a composite validator factory used to create a validator, which will
 // delegate the actual validation to the validator returned by f.
 func Nested[T any](f func(T) Validator) Validator {
-	return Func(func(field *Field) Errors {
-		v, ok := field.Value.(T)
-		if !ok {
-			var want T
-			return NewUnsupportedErrors("Nested", field, want)
-		}
+	return describedFunc{
+		Func: func(field *Field) Errors {
+			v, ok := field.Value.(T)
+			if !ok {
+				var want T
+				return NewUnsupportedErrors("Nested", field, want)
+			}
 
-		return f(v).Validate(field)
-	})
+			return validate(f(v), field)
+		},
+		describe: func() Description {
+			apply := func(value any) (Description, bool) {
+				v, ok := value.(T)
+				if !ok {
+					return Description{}, false
+				}
+				return describeApplied(func() Validator { return f(v) })
+			}
+
+			desc := Description{Code: "nested", Apply: apply}
+			var zero T
+			if d, ok := apply(zero); ok {
+				desc.Validators = []Description{d}
+			}
+			return desc
+		},
+	}
 }
 
 // EachMap is a composite validator factory used to create a validator, which will
@@ -56,24 +134,33 @@ func Nested[T any](f func(T) Validator) Validator {
 // complex validation rules for map elements, such as different validation for
 // each value or validation specific to keys, then you should use Map.
 func EachMap[T map[K]V, K comparable, V any](validator Validator) Validator {
-	return Func(func(field *Field) (errs Errors) {
-		v, ok := field.Value.(T)
-		if !ok {
-			var want T
-			return NewUnsupportedErrors("EachMap", field, want)
-		}
+	return describedFunc{
+		Func: func(field *Field) (errs Errors) {
+			v, ok := field.Value.(T)
+			if !ok {
+				var want T
+				return NewUnsupportedErrors("EachMap", field, want)
+			}
+
+			for _, k := range sortedKeys(v) {
+				if field.run.stopped() {
+					break
+				}
 
-		for k := range v {
-			s := toSchema(v[k], validator)
-			err := validateSchema(s, field, func(name string) string {
-				return name + fmt.Sprintf("[%v]", k)
-			})
-			if err != nil {
-				errs.Append(err...)
+				s := toSchema(v[k.key], validator)
+				err := validateSchema(s, field, func(name string) string {
+					return name + "[" + k.str + "]"
+				})
+				if err != nil {
+					errs.Append(err...)
+				}
 			}
-		}
-		return
-	})
+			return
+		},
+		describe: func() Description {
+			return Description{Code: "each_map", Validators: describeAll(validator)}
+		},
+	}
 }
 
 // EachSlice is a composite validator factory used to create a validator, which will
@@ -83,72 +170,99 @@ func EachMap[T map[K]V, K comparable, V any](validator Validator) Validator {
 // complex validation rules for slice elements, such as different validation for
 // each element, then you should use Slice.
 func EachSlice[T ~[]E, E any](validator Validator) Validator {
-	return Func(func(field *Field) (errs Errors) {
-		v, ok := field.Value.(T)
-		if !ok {
-			var want T
-			return NewUnsupportedErrors("EachSlice", field, want)
-		}
+	return describedFunc{
+		Func: func(field *Field) (errs Errors) {
+			v, ok := field.Value.(T)
+			if !ok {
+				var want T
+				return NewUnsupportedErrors("EachSlice", field, want)
+			}
+
+			for i := range v {
+				if field.run.stopped() {
+					break
+				}
 
-		for i := range v {
-			s := toSchema(v[i], validator)
-			err := validateSchema(s, field, func(name string) string {
-				return name + "[" + strconv.Itoa(i) + "]"
-			})
-			if err != nil {
-				errs.Append(err...)
+				s := toSchema(v[i], validator)
+				err := validateSchema(s, field, func(name string) string {
+					return name + "[" + strconv.Itoa(i) + "]"
+				})
+				if err != nil {
+					errs.Append(err...)
+				}
 			}
-		}
-		return
-	})
+			return
+		},
+		describe: func() Description {
+			return Description{Code: "each_slice", Validators: describeAll(validator)}
+		},
+	}
 }
 
 // Map is a composite validator factory used to create a validator, which will
 // do the validation per the schemas associated with a map.
 func Map[T map[K]V, K comparable, V any](f func(T) map[K]Validator) Validator {
-	return Func(func(field *Field) (errs Errors) {
-		v, ok := field.Value.(T)
-		if !ok {
-			var want T
-			return NewUnsupportedErrors("Map", field, want)
-		}
+	return describedFunc{
+		Func: func(field *Field) (errs Errors) {
+			v, ok := field.Value.(T)
+			if !ok {
+				var want T
+				return NewUnsupportedErrors("Map", field, want)
+			}
+
+			validators := f(v)
+			for _, k := range sortedKeys(validators) {
+				if field.run.stopped() {
+					break
+				}
 
-		validators := f(v)
-		for k, validator := range validators {
-			s := toSchema(v[k], validator)
-			err := validateSchema(s, field, func(name string) string {
-				return name + fmt.Sprintf("[%v]", k)
-			})
-			if err != nil {
-				errs.Append(err...)
+				s := toSchema(v[k.key], validators[k.key])
+				err := validateSchema(s, field, func(name string) string {
+					return name + "[" + k.str + "]"
+				})
+				if err != nil {
+					errs.Append(err...)
+				}
 			}
-		}
-		return
-	})
+			return
+		},
+		describe: func() Description {
+			return Description{Code: "map"}
+		},
+	}
 }
 
 // Slice is a composite validator factory used to create a validator, which will
 // do the validation per the schemas associated with a slice.
 func Slice[T ~[]E, E any](f func(T) []Validator) Validator {
-	return Func(func(field *Field) (errs Errors) {
-		v, ok := field.Value.(T)
-		if !ok {
-			var want T
-			return NewUnsupportedErrors("Slice", field, want)
-		}
+	return describedFunc{
+		Func: func(field *Field) (errs Errors) {
+			v, ok := field.Value.(T)
+			if !ok {
+				var want T
+				return NewUnsupportedErrors("Slice", field, want)
+			}
+
+			validators := f(v)
+			for i, validator := range validators {
+				if field.run.stopped() {
+					break
+				}
 
-		validators := f(v)
-		for i, validator := range validators {
-			s := toSchema(v[i], validator)
-			err := validateSchema(s, field, func(name string) string {
-				return name + "[" + strconv.Itoa(i) + "]"
-			})
-			if err != nil {
-				errs.Append(err...)
+				s := toSchema(v[i], validator)
+				err := validateSchema(s, field, func(name string) string {
+					return name + "[" + strconv.Itoa(i) + "]"
+				})
+				if err != nil {
+					errs.Append(err...)
+				}
 			}
-		}
-		return
-	})
+			return
+		},
+		describe: func() Description {
+			return Description{Code: "slice"}
+		},
+	}
 }
 
 // Array is an alias of Slice.
@@ -161,9 +275,22 @@ func Array[T ~[]E, E any](f func(T) []Validator) Validator {
 type MessageValidator struct {
 	Message   string
 	Validator Validator
+
+	// Code is a stable machine-readable code, which identifies the rule
+	// enforced by the validator (e.g. "len_string").
+	Code string
+	// Params holds the parameters of the rule (e.g. the bounds of a range).
+	Params map[string]any
+
+	validators []Validator // The sub-validators, for introspection only.
 }
 
 // Msg sets the INVALID error message.
+//
+// The message can also be a template containing placeholders, such as
+// "must be between {min} and {max}", which will be rendered at error time.
+// A placeholder will be replaced with the corresponding parameter of the
+// validator (see Params), or with the offending value if it's {value}.
 func (mv *MessageValidator) Msg(msg string) *MessageValidator {
 	if msg != "" {
 		mv.Message = msg
@@ -171,22 +298,70 @@ func (mv *MessageValidator) Msg(msg string) *MessageValidator {
 	return mv
 }
 
+// Invalid returns the INVALID errors for the given field, which carry
+// the (translated and rendered) message, the code and the parameters of
+// the validator.
+func (mv *MessageValidator) Invalid(field *Field) Errors {
+	// Default messages are translated by code, while custom messages
+	// are translated by themselves.
+	key := mv.Message
+	if mv.Code != "" && mv.Message == defaultMessages[mv.Code] {
+		key = mv.Code
+	}
+
+	msg := mv.Message
+	if s, ok := field.run.translate(key); ok {
+		msg = s
+	}
+	msg = renderMessage(msg, mv.Params, field.Value)
+
+	return Errors{NewCodedError(field.Name, ErrInvalid, msg, mv.Code, copyParams(mv.Params))}
+}
+
+// copyParams returns a copy of params, including the slices of values in it,
+// so that changing the parameters of an error never affects the validator.
+func copyParams(params map[string]any) map[string]any {
+	if params == nil {
+		return nil
+	}
+	m := make(map[string]any, len(params))
+	for k, p := range params {
+		switch x := p.(type) {
+		case []any:
+			if x != nil {
+				p = append(make([]any, 0, len(x)), x...)
+			}
+		case []string:
+			if x != nil {
+				p = append(make([]string, 0, len(x)), x...)
+			}
+		}
+		m[k] = p
+	}
+	return m
+}
+
 // Validate delegates the actual validation to its inner validator.
 func (mv *MessageValidator) Validate(field *Field) Errors {
-	return mv.Validator.Validate(field)
+	return validate(mv.Validator, field)
 }
 
 // All is a composite validator factory used to create a validator, which will
 // succeed only when all sub-validators succeed.
 func All(validators ...Validator) Validator {
-	return Func(func(field *Field) Errors {
-		for _, v := range validators {
-			if errs := v.Validate(field); errs != nil {
-				return errs
+	return describedFunc{
+		Func: func(field *Field) Errors {
+			for _, v := range validators {
+				if errs := validate(v, field); errs != nil {
+					return errs
+				}
 			}
-		}
-		return nil
-	})
+			return nil
+		},
+		describe: func() Description {
+			return Description{Code: "all", Validators: describeAll(validators...)}
+		},
+	}
 }
 
 // And is an alias of All.
@@ -217,8 +392,14 @@ func (av *AnyValidator) Validate(field *Field) Errors {
 	var errs Errors
 	var lastErr Errors
 
+	// Errors from a failed sub-validator do not count, since they may be
+	// discarded once a subsequent sub-validator succeeds.
+	n := field.run.count()
+	defer field.run.setCount(n)
+
 	for _, v := range av.validators {
-		lastErr = v.Validate(field)
+		field.run.setCount(n)
+		lastErr = validate(v, field)
 		if lastErr == nil {
 			return nil
 		}
@@ -234,15 +415,122 @@ func (av *AnyValidator) Validate(field *Field) Errors {
 // Or is an alias of Any.
 var Or = Any
 
+// ConditionalValidator is a validator, which delegates the validation to
+// one of its branches according to a condition. It's created by When, If or
+// Unless.
+type ConditionalValidator struct {
+	cond      func() bool
+	negated   bool   // Whether the branches are chosen by the negated condition.
+	condition string // The description of the condition.
+	then      Validator
+	els       Validator
+}
+
+// When is a composite validator factory used to create a validator, which will
+// delegate the validation to then if cond is true, or to the validator set by
+// Else (if any) otherwise.
+func When(cond bool, then Validator) *ConditionalValidator {
+	return &ConditionalValidator{cond: func() bool { return cond }, then: then}
+}
+
+// If is like When, except that the condition is evaluated by calling cond
+// at validation time. If cond is a named function (e.g. isCompany), its name
+// is used as the description of the condition unless Cond is called.
+func If(cond func() bool, then Validator) *ConditionalValidator {
+	return &ConditionalValidator{cond: cond, condition: funcName(cond), then: then}
+}
+
+// Unless is the opposite of When, which will delegate the validation to then
+// if cond is false, or to the validator set by Else (if any) otherwise.
+func Unless(cond bool, then Validator) *ConditionalValidator {
+	return &ConditionalValidator{cond: func() bool { return cond }, negated: true, then: then}
+}
+
+// Else sets the validator to use if the condition does not hold.
+func (cv *ConditionalValidator) Else(validator Validator) *ConditionalValidator {
+	cv.els = validator
+	return cv
+}
+
+// Cond sets the human-readable description of the condition, which will be
+// shown in the description of the validator (see Describe). Without it, the
+// condition is described as UnspecifiedCondition.
+func (cv *ConditionalValidator) Cond(description string) *ConditionalValidator {
+	cv.condition = description
+	return cv
+}
+
+// Validate delegates the actual validation to the branch chosen by the
+// condition.
+func (cv *ConditionalValidator) Validate(field *Field) Errors {
+	if cv.cond() != cv.negated {
+		return validate(cv.then, field)
+	}
+	if cv.els != nil {
+		return validate(cv.els, field)
+	}
+	return nil
+}
+
+// UnspecifiedCondition is the description of the conditions, which are not
+// described by ConditionalValidator.Cond.
+const UnspecifiedCondition = "an unspecified condition holds"
+
+// funcName returns the name of the given function, or an empty string if
+// it's anonymous.
+func funcName(f func() bool) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	name = strings.TrimSuffix(name[strings.LastIndexByte(name, '.')+1:], "-fm") // Method values end with "-fm".
+	if anonymousFunc.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// anonymousFunc matches the names of anonymous functions (e.g. "func1").
+var anonymousFunc = regexp.MustCompile(`^func\d+$`)
+
+// Always is a composite validator factory used to create a validator, which
+// delegates the actual validation to the given validator, but is always
+// applied even if the field is not selected by WithFields. The same is true
+// for the nested validators of the given one.
+//
+// Always only takes effect when it's associated with a field in a schema:
+//
+//	v.Schema{
+//		v.F("updated_at", p.UpdatedAt): v.Always(v.Nonzero[time.Time]()),
+//	}
+func Always(validator Validator) Validator {
+	return alwaysValidator{Validator: validator}
+}
+
+type alwaysValidator struct {
+	Validator
+}
+
+func (av alwaysValidator) Validate(field *Field) Errors {
+	return field.run.unfiltered(func() Errors {
+		return validate(av.Validator, field)
+	})
+}
+
 // Not is a composite validator factory used to create a validator, which will
 // succeed when the given validator fails.
 func Not(validator Validator) (mv *MessageValidator) {
 	mv = &MessageValidator{
-		Message: "is invalid",
+		Message:    "is invalid",
+		Code:       "not",
+		validators: []Validator{validator},
 		Validator: Func(func(field *Field) Errors {
-			errs := validator.Validate(field)
+			n := field.run.count()
+			errs := validate(validator, field)
+			field.run.setCount(n)
 			if len(errs) == 0 {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 
 			var newErrs Errors
@@ -263,6 +551,7 @@ func Not(validator Validator) (mv *MessageValidator) {
 func Is[T any](f func(T) bool) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is invalid",
+		Code:    "is",
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -271,7 +560,7 @@ func Is[T any](f func(T) bool) (mv *MessageValidator) {
 			}
 
 			if !f(v) {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -279,11 +568,30 @@ func Is[T any](f func(T) bool) (mv *MessageValidator) {
 	return
 }
 
+// Fail is a leaf validator factory used to create a validator, which will
+// always fail with the default message of code, rendered with params.
+//
+// It's useful for reporting failures detected outside of validators (e.g.
+// parsing errors) in the same way as the built-in validators, including the
+// translation of the messages by code.
+func Fail(code string, params map[string]any) (mv *MessageValidator) {
+	mv = &MessageValidator{
+		Message: defaultMessages[code],
+		Code:    code,
+		Params:  params,
+		Validator: Func(func(field *Field) Errors {
+			return mv.Invalid(field)
+		}),
+	}
+	return
+}
+
 // Nonzero is a leaf validator factory used to create a validator, which will
 // succeed when the field's value is nonzero.
 func Nonzero[T comparable]() (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is zero valued",
+		Code:    "nonzero",
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -293,7 +601,7 @@ func Nonzero[T comparable]() (mv *MessageValidator) {
 
 			var zero T
 			if v == zero {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -306,6 +614,7 @@ func Nonzero[T comparable]() (mv *MessageValidator) {
 func Zero[T comparable]() (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is nonzero",
+		Code:    "zero",
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -315,7 +624,7 @@ func Zero[T comparable]() (mv *MessageValidator) {
 
 			var zero T
 			if v != zero {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -336,6 +645,8 @@ func ZeroOr[T comparable](validator Validator) Validator {
 func LenString(min, max int) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "has an invalid length",
+		Code:    "len_string",
+		Params:  map[string]any{"min": min, "max": max},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(string)
 			if !ok {
@@ -344,7 +655,7 @@ func LenString(min, max int) (mv *MessageValidator) {
 
 			l := len(v)
 			if l < min || l > max {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -357,6 +668,8 @@ func LenString(min, max int) (mv *MessageValidator) {
 func LenSlice[T ~[]E, E any](min, max int) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "has an invalid length",
+		Code:    "len_slice",
+		Params:  map[string]any{"min": min, "max": max},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -366,7 +679,7 @@ func LenSlice[T ~[]E, E any](min, max int) (mv *MessageValidator) {
 
 			l := len(v)
 			if l < min || l > max {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -379,6 +692,8 @@ func LenSlice[T ~[]E, E any](min, max int) (mv *MessageValidator) {
 func RuneCount(min, max int) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "the number of runes is not between the given range",
+		Code:    "rune_count",
+		Params:  map[string]any{"min": min, "max": max},
 		Validator: Func(func(field *Field) Errors {
 			valid := false
 
@@ -394,7 +709,7 @@ func RuneCount(min, max int) (mv *MessageValidator) {
 			}
 
 			if !valid {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -407,6 +722,8 @@ func RuneCount(min, max int) (mv *MessageValidator) {
 func Eq[T comparable](value T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "does not equal the given value",
+		Code:    "eq",
+		Params:  map[string]any{"eq": value},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -415,7 +732,7 @@ func Eq[T comparable](value T) (mv *MessageValidator) {
 			}
 
 			if v != value {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -428,6 +745,8 @@ func Eq[T comparable](value T) (mv *MessageValidator) {
 func Ne[T comparable](value T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "equals the given value",
+		Code:    "ne",
+		Params:  map[string]any{"ne": value},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -436,7 +755,7 @@ func Ne[T comparable](value T) (mv *MessageValidator) {
 			}
 
 			if v == value {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -449,6 +768,8 @@ func Ne[T comparable](value T) (mv *MessageValidator) {
 func Gt[T constraints.Ordered](value T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is lower than or equal to the given value",
+		Code:    "gt",
+		Params:  map[string]any{"gt": value},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -457,7 +778,7 @@ func Gt[T constraints.Ordered](value T) (mv *MessageValidator) {
 			}
 
 			if v <= value {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -470,6 +791,8 @@ func Gt[T constraints.Ordered](value T) (mv *MessageValidator) {
 func Gte[T constraints.Ordered](value T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is lower than the given value",
+		Code:    "gte",
+		Params:  map[string]any{"gte": value},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -478,7 +801,7 @@ func Gte[T constraints.Ordered](value T) (mv *MessageValidator) {
 			}
 
 			if v < value {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -491,6 +814,8 @@ func Gte[T constraints.Ordered](value T) (mv *MessageValidator) {
 func Lt[T constraints.Ordered](value T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is greater than or equal to the given value",
+		Code:    "lt",
+		Params:  map[string]any{"lt": value},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -499,7 +824,7 @@ func Lt[T constraints.Ordered](value T) (mv *MessageValidator) {
 			}
 
 			if v >= value {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -512,6 +837,8 @@ func Lt[T constraints.Ordered](value T) (mv *MessageValidator) {
 func Lte[T constraints.Ordered](value T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is greater than the given value",
+		Code:    "lte",
+		Params:  map[string]any{"lte": value},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -520,7 +847,7 @@ func Lte[T constraints.Ordered](value T) (mv *MessageValidator) {
 			}
 
 			if v > value {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -533,6 +860,8 @@ func Lte[T constraints.Ordered](value T) (mv *MessageValidator) {
 func Range[T constraints.Ordered](min, max T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is not between the given range",
+		Code:    "range",
+		Params:  map[string]any{"min": min, "max": max},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -541,7 +870,51 @@ func Range[T constraints.Ordered](min, max T) (mv *MessageValidator) {
 			}
 
 			if v < min || v > max {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
+			}
+			return nil
+		}),
+	}
+	return
+}
+
+// Before is a leaf validator factory used to create a validator, which will
+// succeed when the field's value (of type time.Time) is before the given time.
+func Before(t time.Time) (mv *MessageValidator) {
+	mv = &MessageValidator{
+		Message: "is not before the given time",
+		Code:    "before",
+		Params:  map[string]any{"before": t},
+		Validator: Func(func(field *Field) Errors {
+			v, ok := field.Value.(time.Time)
+			if !ok {
+				return NewUnsupportedErrors("Before", field, time.Time{})
+			}
+
+			if !v.Before(t) {
+				return mv.Invalid(field)
+			}
+			return nil
+		}),
+	}
+	return
+}
+
+// After is a leaf validator factory used to create a validator, which will
+// succeed when the field's value (of type time.Time) is after the given time.
+func After(t time.Time) (mv *MessageValidator) {
+	mv = &MessageValidator{
+		Message: "is not after the given time",
+		Code:    "after",
+		Params:  map[string]any{"after": t},
+		Validator: Func(func(field *Field) Errors {
+			v, ok := field.Value.(time.Time)
+			if !ok {
+				return NewUnsupportedErrors("After", field, time.Time{})
+			}
+
+			if !v.After(t) {
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -554,6 +927,8 @@ func Range[T constraints.Ordered](min, max T) (mv *MessageValidator) {
 func In[T comparable](values ...T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is not one of the given values",
+		Code:    "in",
+		Params:  map[string]any{"values": toAnys(values)},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -570,7 +945,7 @@ func In[T comparable](values ...T) (mv *MessageValidator) {
 			}
 
 			if !valid {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -583,6 +958,8 @@ func In[T comparable](values ...T) (mv *MessageValidator) {
 func Nin[T comparable](values ...T) (mv *MessageValidator) {
 	mv = &MessageValidator{
 		Message: "is one of the given values",
+		Code:    "nin",
+		Params:  map[string]any{"values": toAnys(values)},
 		Validator: Func(func(field *Field) Errors {
 			v, ok := field.Value.(T)
 			if !ok {
@@ -599,7 +976,7 @@ func Nin[T comparable](values ...T) (mv *MessageValidator) {
 			}
 
 			if !valid {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -623,6 +1000,8 @@ func Match(pattern any) (mv *MessageValidator) {
 
 	mv = &MessageValidator{
 		Message: "does not match the given regular expression",
+		Code:    "match",
+		Params:  map[string]any{"pattern": re.String()},
 		Validator: Func(func(field *Field) Errors {
 			valid := false
 
@@ -636,7 +1015,7 @@ func Match(pattern any) (mv *MessageValidator) {
 			}
 
 			if !valid {
-				return NewInvalidErrors(field, mv.Message)
+				return mv.Invalid(field)
 			}
 			return nil
 		}),
@@ -644,31 +1023,101 @@ func Match(pattern any) (mv *MessageValidator) {
 	return
 }
 
-// toSchema converts the given validator to a Schema if it's not already.
-func toSchema(value any, validator Validator) Schema {
-	s, ok := validator.(Schema)
+// schema is a common interface for Schema and OrderedSchema.
+type schema interface {
+	Validator
+
+	// each calls f for each field, along with its validator, until f
+	// returns false.
+	each(f func(field *Field, validator Validator) bool)
+}
+
+// toAnys converts the given values to a slice of type []any.
+func toAnys[T any](values []T) []any {
+	anys := make([]any, len(values))
+	for i, v := range values {
+		anys[i] = v
+	}
+	return anys
+}
+
+// toSchema converts the given validator to a schema if it's not already.
+func toSchema(value any, validator Validator) schema {
+	s, ok := validator.(schema)
 	if !ok {
 		s = Value(value, validator)
 	}
 	return s
 }
 
+// mapKey is a map key along with its string representation.
+type mapKey[K comparable] struct {
+	key K
+	str string
+}
+
+// less reports whether k sorts before other. Keys of the same ordered kind
+// (i.e. integers, floats and strings) are compared natively, while the others
+// are compared by their string representations.
+func (k mapKey[K]) less(other mapKey[K]) bool {
+	a, b := reflect.ValueOf(k.key), reflect.ValueOf(other.key)
+	if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+	}
+	return k.str < other.str
+}
+
+// sortedKeys returns the sorted keys of m (see mapKey.less), which makes the
+// iteration order deterministic.
+func sortedKeys[K comparable, V any](m map[K]V) []mapKey[K] {
+	keys := make([]mapKey[K], 0, len(m))
+	for k := range m {
+		keys = append(keys, mapKey[K]{key: k, str: fmt.Sprintf("%v", k)})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+	return keys
+}
+
 // validateSchema do the validation per the given schema, which is associated
 // with the given field.
-func validateSchema(schema Schema, field *Field, prefixFunc func(string) string) (errs Errors) {
+func validateSchema(schema schema, field *Field, prefixFunc func(string) string) (errs Errors) {
 	prefix := prefixFunc(field.Name)
+	r := field.run
+	n := r.count()
 
-	for f, v := range schema {
+	schema.each(func(f *Field, v Validator) bool {
+		if r.stopped() {
+			return false
+		}
+
+		name := f.Name
 		if prefix != "" {
-			name := prefix
+			name = prefix
 			if f.Name != "" {
 				name = name + "." + f.Name
 			}
-			f = F(name, f.Value)
 		}
-		if err := v.Validate(f); err != nil {
+		if _, ok := v.(alwaysValidator); !ok && !r.selected(name) {
+			return true
+		}
+		f = &Field{Name: name, Value: f.Value, run: r}
+
+		if err := validate(v, f); err != nil {
 			errs.Append(err...)
 		}
-	}
+		r.setCount(n + len(errs))
+		return true
+	})
 	return
 }
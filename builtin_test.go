@@ -15,12 +15,26 @@ func makeErrsMap(errs v.Errors) map[string]v.Error {
 	}
 
 	formatted := make(map[string]v.Error, len(errs))
-	for _, err := range errs {
+	for _, err := range plainErrs(errs) {
 		formatted[err.Field()] = err
 	}
 	return formatted
 }
 
+// plainErrs strips the codes and the parameters from the given errors,
+// which are covered separately by TestCodedError.
+func plainErrs(errs v.Errors) v.Errors {
+	if errs == nil {
+		return nil
+	}
+
+	plain := make(v.Errors, len(errs))
+	for i, err := range errs {
+		plain[i] = v.NewError(err.Field(), err.Kind(), err.Message())
+	}
+	return plain
+}
+
 func TestNested(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -59,6 +73,75 @@ func TestNested(t *testing.T) {
 	}
 }
 
+func TestOrderedSchema(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema v.OrderedSchema
+		errs   v.Errors
+	}{
+		{
+			name:   "empty",
+			schema: v.OrderedSchema{},
+			errs:   nil,
+		},
+		{
+			name: "declaration order",
+			schema: v.OrderedSchema{}.
+				Add(v.F("c", ""), v.Nonzero[string]()).
+				Add(v.F("a", 0), v.Nonzero[int]()).
+				Add(v.F("b", "x"), v.LenString(2, 5)),
+			errs: v.Errors{
+				v.NewError("c", v.ErrInvalid, "is zero valued"),
+				v.NewError("a", v.ErrInvalid, "is zero valued"),
+				v.NewError("b", v.ErrInvalid, "has an invalid length"),
+			},
+		},
+		{
+			name: "sorted map keys",
+			schema: v.OrderedSchema{}.
+				Add(v.F("each", map[string]int{"k3": 0, "k1": 0, "k2": 0}), v.EachMap[map[string]int](v.Nonzero[int]())).
+				Add(v.F("map", map[int]int{10: 0, 2: 0}), v.Map(func(m map[int]int) map[int]v.Validator {
+					return map[int]v.Validator{10: v.Nonzero[int](), 2: v.Nonzero[int]()}
+				})).
+				Add(v.F("floats", map[float64]int{1.5: 0, -2: 0}), v.EachMap[map[float64]int](v.Nonzero[int]())).
+				Add(v.F("months", map[time.Month]int{time.April: 0, time.January: 0}), v.EachMap[map[time.Month]int](v.Nonzero[int]())),
+			errs: v.Errors{
+				v.NewError("each[k1]", v.ErrInvalid, "is zero valued"),
+				v.NewError("each[k2]", v.ErrInvalid, "is zero valued"),
+				v.NewError("each[k3]", v.ErrInvalid, "is zero valued"),
+				v.NewError("map[2]", v.ErrInvalid, "is zero valued"),
+				v.NewError("map[10]", v.ErrInvalid, "is zero valued"),
+				v.NewError("floats[-2]", v.ErrInvalid, "is zero valued"),
+				v.NewError("floats[1.5]", v.ErrInvalid, "is zero valued"),
+				v.NewError("months[January]", v.ErrInvalid, "is zero valued"),
+				v.NewError("months[April]", v.ErrInvalid, "is zero valued"),
+			},
+		},
+		{
+			name: "nested",
+			schema: v.OrderedSchema{
+				{Field: v.F("outer", struct{ Foo, Bar int }{}), Validator: v.Nested(func(s struct{ Foo, Bar int }) v.Validator {
+					return v.OrderedSchema{}.
+						Add(v.F("foo", s.Foo), v.Nonzero[int]()).
+						Add(v.F("bar", s.Bar), v.Nonzero[int]())
+				})},
+			},
+			errs: v.Errors{
+				v.NewError("outer.foo", v.ErrInvalid, "is zero valued"),
+				v.NewError("outer.bar", v.ErrInvalid, "is zero valued"),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(c.schema)
+			if !reflect.DeepEqual(plainErrs(errs), c.errs) {
+				t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+			}
+		})
+	}
+}
+
 func TestEachMap(t *testing.T) {
 	type Stat struct {
 		Count int
@@ -460,6 +543,79 @@ func TestAny(t *testing.T) {
 	}
 }
 
+func TestWhen_If_Unless(t *testing.T) {
+	type Customer struct {
+		Type  string
+		VatID string
+	}
+	schema := func(c Customer) v.Validator {
+		return v.Schema{
+			v.F("type", c.Type): v.In("person", "company"),
+			v.F("vat_id", c.VatID): v.When(c.Type == "company", v.Nonzero[string]()).
+				Else(v.Zero[string]()),
+		}
+	}
+
+	// The condition of If is evaluated at validation time.
+	enabled := false
+	lazy := v.Schema{
+		v.F("value", ""): v.If(func() bool { return enabled }, v.Nonzero[string]()),
+	}
+	enabled = true
+
+	cases := []struct {
+		schema v.Schema
+		errs   v.Errors
+	}{
+		{
+			v.Schema{
+				v.F("customer", Customer{Type: "company", VatID: "x"}): v.Nested(schema),
+			},
+			nil,
+		},
+		{
+			v.Schema{
+				v.F("customer", Customer{Type: "company"}): v.Nested(schema),
+			},
+			v.NewErrors("customer.vat_id", v.ErrInvalid, "is zero valued"),
+		},
+		{
+			v.Schema{
+				v.F("customer", Customer{Type: "person", VatID: "x"}): v.Nested(schema),
+			},
+			v.NewErrors("customer.vat_id", v.ErrInvalid, "is nonzero"),
+		},
+		{
+			v.Schema{
+				v.F("value", ""): v.When(false, v.Nonzero[string]()),
+			},
+			nil,
+		},
+		{
+			v.Schema{
+				v.F("value", ""): v.Unless(false, v.Nonzero[string]()),
+			},
+			v.NewErrors("value", v.ErrInvalid, "is zero valued"),
+		},
+		{
+			v.Schema{
+				v.F("value", ""): v.Unless(true, v.Nonzero[string]()).Else(v.LenString(1, 2)),
+			},
+			v.NewErrors("value", v.ErrInvalid, "has an invalid length"),
+		},
+		{
+			lazy,
+			v.NewErrors("value", v.ErrInvalid, "is zero valued"),
+		},
+	}
+	for _, c := range cases {
+		errs := v.Validate(c.schema)
+		if !reflect.DeepEqual(makeErrsMap(errs), makeErrsMap(c.errs)) {
+			t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+		}
+	}
+}
+
 func TestNot(t *testing.T) {
 	cases := []struct {
 		schema v.Schema
@@ -548,6 +704,52 @@ func TestIs(t *testing.T) {
 	}
 }
 
+func TestFail(t *testing.T) {
+	catalog := v.NewCatalog()
+	catalog.Register("de", map[string]string{
+		"type": "muss vom Typ {expected} sein",
+	})
+
+	cases := []struct {
+		name      string
+		validator v.Validator
+		opts      []v.Option
+		errs      v.Errors
+	}{
+		{
+			name:      "default message",
+			validator: v.Fail("type", map[string]any{"expected": "integer"}),
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "must be of type integer", "type", map[string]any{"expected": "integer"}),
+			},
+		},
+		{
+			name:      "translated message",
+			validator: v.Fail("type", map[string]any{"expected": "integer"}),
+			opts:      []v.Option{v.WithLocale("de"), v.WithTranslator(catalog)},
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "muss vom Typ integer sein", "type", map[string]any{"expected": "integer"}),
+			},
+		},
+		{
+			name:      "custom message",
+			validator: v.Fail("type", map[string]any{"expected": "integer"}).Msg("not a number"),
+			opts:      []v.Option{v.WithLocale("de"), v.WithTranslator(catalog)},
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "not a number", "type", map[string]any{"expected": "integer"}),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(v.Value("x", c.validator), c.opts...)
+			if !reflect.DeepEqual(errs, c.errs) {
+				t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+			}
+		})
+	}
+}
+
 func TestNonzero(t *testing.T) {
 	cases := []struct {
 		value     any
@@ -1281,3 +1483,171 @@ func TestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestCodedError(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     any
+		validator v.Validator
+		code      string
+		params    map[string]any
+	}{
+		{
+			name:      "Not",
+			value:     1,
+			validator: v.Not(v.Eq(1)),
+			code:      "not",
+		},
+		{
+			name:      "Is",
+			value:     1,
+			validator: v.Is(func(int) bool { return false }),
+			code:      "is",
+		},
+		{
+			name:      "Nonzero",
+			value:     0,
+			validator: v.Nonzero[int](),
+			code:      "nonzero",
+		},
+		{
+			name:      "Zero",
+			value:     1,
+			validator: v.Zero[int](),
+			code:      "zero",
+		},
+		{
+			name:      "LenString",
+			value:     "",
+			validator: v.LenString(1, 5),
+			code:      "len_string",
+			params:    map[string]any{"min": 1, "max": 5},
+		},
+		{
+			name:      "LenSlice",
+			value:     []int(nil),
+			validator: v.LenSlice[[]int](1, 5),
+			code:      "len_slice",
+			params:    map[string]any{"min": 1, "max": 5},
+		},
+		{
+			name:      "RuneCount",
+			value:     "",
+			validator: v.RuneCount(1, 5),
+			code:      "rune_count",
+			params:    map[string]any{"min": 1, "max": 5},
+		},
+		{
+			name:      "Eq",
+			value:     1,
+			validator: v.Eq(2),
+			code:      "eq",
+			params:    map[string]any{"eq": 2},
+		},
+		{
+			name:      "Ne",
+			value:     1,
+			validator: v.Ne(1),
+			code:      "ne",
+			params:    map[string]any{"ne": 1},
+		},
+		{
+			name:      "Gt",
+			value:     1,
+			validator: v.Gt(1),
+			code:      "gt",
+			params:    map[string]any{"gt": 1},
+		},
+		{
+			name:      "Gte",
+			value:     1,
+			validator: v.Gte(2),
+			code:      "gte",
+			params:    map[string]any{"gte": 2},
+		},
+		{
+			name:      "Lt",
+			value:     1,
+			validator: v.Lt(1),
+			code:      "lt",
+			params:    map[string]any{"lt": 1},
+		},
+		{
+			name:      "Lte",
+			value:     2,
+			validator: v.Lte(1),
+			code:      "lte",
+			params:    map[string]any{"lte": 1},
+		},
+		{
+			name:      "Range",
+			value:     0,
+			validator: v.Range(1, 5),
+			code:      "range",
+			params:    map[string]any{"min": 1, "max": 5},
+		},
+		{
+			name:      "In",
+			value:     "c",
+			validator: v.In("a", "b"),
+			code:      "in",
+			params:    map[string]any{"values": []any{"a", "b"}},
+		},
+		{
+			name:      "Nin",
+			value:     "a",
+			validator: v.Nin("a", "b"),
+			code:      "nin",
+			params:    map[string]any{"values": []any{"a", "b"}},
+		},
+		{
+			name:      "Match",
+			value:     "a",
+			validator: v.Match(`^\d+$`),
+			code:      "match",
+			params:    map[string]any{"pattern": `^\d+$`},
+		},
+		{
+			name:      "custom message",
+			value:     "",
+			validator: v.LenString(1, 5).Msg("bad length"),
+			code:      "len_string",
+			params:    map[string]any{"min": 1, "max": 5},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(v.Value(c.value, c.validator))
+			if len(errs) != 1 {
+				t.Fatalf("Got %d errors != Want 1 error", len(errs))
+			}
+			err, ok := errs[0].(v.CodedError)
+			if !ok {
+				t.Fatalf("Got error (%T) does not implement CodedError", errs[0])
+			}
+			if err.Code() != c.code {
+				t.Errorf("Got code (%s) != Want code (%s)", err.Code(), c.code)
+			}
+			if !reflect.DeepEqual(err.Params(), c.params) {
+				t.Errorf("Got params (%+v) != Want params (%+v)", err.Params(), c.params)
+			}
+		})
+	}
+}
+
+func TestCodedError_ParamsCopied(t *testing.T) {
+	validator := v.In("a", "b").Msg("must be one of {values}")
+
+	errs := v.Validate(v.Value("c", validator))
+	params := errs[0].(v.CodedError).Params()
+	params["values"].([]any)[0] = "x"
+	params["extra"] = true
+
+	errs = v.Validate(v.Value("c", validator))
+	want := v.Errors{
+		v.NewCodedError("", v.ErrInvalid, "must be one of a, b", "in", map[string]any{"values": []any{"a", "b"}}),
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Got (%+v) != Want (%+v)", errs, want)
+	}
+}
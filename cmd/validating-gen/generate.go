@@ -0,0 +1,812 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RussellLuo/validating/v3/vrule"
+)
+
+const (
+	tagName       = "validate"
+	commentPrefix = "//validating:"
+	importPath    = "github.com/RussellLuo/validating/v3"
+)
+
+// Generate generates the source code of Schema methods for the annotated
+// structs declared in the given files, which must belong to one package.
+func Generate(files []string) ([]byte, error) {
+	g := &generator{
+		fset:    token.NewFileSet(),
+		decls:   make(map[string]ast.Expr),
+		targets: make(map[string]bool),
+		imports: map[string]string{"math": "math"},
+	}
+
+	var structs []*structDecl
+	for _, name := range files {
+		f, err := parser.ParseFile(g.fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if g.pkg == "" {
+			g.pkg = f.Name.Name
+		} else if g.pkg != f.Name.Name {
+			return nil, fmt.Errorf("%s: package %s, want %s", name, f.Name.Name, g.pkg)
+		}
+
+		for _, spec := range f.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			g.imports[name] = path
+		}
+
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				g.decls[ts.Name.Name] = ts.Type
+				if st, ok := ts.Type.(*ast.StructType); ok && ts.TypeParams == nil {
+					structs = append(structs, &structDecl{name: ts.Name.Name, typ: st})
+				}
+			}
+		}
+	}
+
+	// Find all the annotated structs first, since they may refer to each
+	// other.
+	for _, s := range structs {
+		if g.annotated(s.typ, nil) {
+			g.targets[s.name] = true
+		}
+	}
+
+	var body bytes.Buffer
+	for _, s := range structs {
+		if !g.targets[s.name] {
+			continue
+		}
+		if err := g.generate(&body, s); err != nil {
+			return nil, err
+		}
+	}
+
+	paths, err := g.usedImports(body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by validating-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg)
+	fmt.Fprintf(&buf, "import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	fmt.Fprintf(&buf, "\n\tv %q\n)\n", importPath)
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %v\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+type structDecl struct {
+	name string
+	typ  *ast.StructType
+}
+
+type generator struct {
+	fset    *token.FileSet
+	pkg     string
+	decls   map[string]ast.Expr // The declared types.
+	targets map[string]bool     // The structs to generate Schema methods for.
+	imports map[string]string   // The import paths keyed by package names.
+}
+
+// usedImports returns the import paths used by the generated body.
+func (g *generator) usedImports(body []byte) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", append([]byte("package p\n"), body...), 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %v\n%s", err, body)
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				if path, ok := g.imports[x.Name]; ok && x.Name != "v" {
+					used[path] = true
+				}
+			}
+		}
+		return true
+	})
+
+	var paths []string
+	for path := range used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// annotation returns the rule string of the field, from either its tag or
+// its //validating: comments.
+func annotation(field *ast.Field) (string, bool) {
+	var rules []string
+	if field.Tag != nil {
+		tag, _ := strconv.Unquote(field.Tag.Value)
+		if r, ok := reflect.StructTag(tag).Lookup(tagName); ok {
+			rules = append(rules, r)
+		}
+	}
+	for _, cg := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if cg == nil {
+			continue
+		}
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, commentPrefix) {
+				rules = append(rules, strings.TrimSpace(strings.TrimPrefix(c.Text, commentPrefix)))
+			}
+		}
+	}
+	return strings.Join(rules, ","), len(rules) > 0
+}
+
+// jsonName returns the JSON name of the field, or its Go name otherwise.
+func jsonName(field *ast.Field, name string) string {
+	if jn := jsonTagName(field); jn != "" {
+		return jn
+	}
+	return name
+}
+
+// jsonTagName returns the name of the field specified in its json tag, if
+// any.
+func jsonTagName(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	jn, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	if jn == "-" {
+		return ""
+	}
+	return jn
+}
+
+// embeddedName returns the Go name of the embedded field of type t.
+func embeddedName(t ast.Expr) (string, bool) {
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch tt := t.(type) {
+	case *ast.Ident:
+		return tt.Name, true
+	case *ast.SelectorExpr:
+		return tt.Sel.Name, true
+	default:
+		return "", false
+	}
+}
+
+// flattened reports whether the embedded field is flattened, as encoding/json
+// does, i.e. it's an untagged embedded struct (not a pointer).
+func flattened(field *ast.Field) bool {
+	_, annotated := annotation(field)
+	_, isPtr := field.Type.(*ast.StarExpr)
+	return len(field.Names) == 0 && !annotated && jsonTagName(field) == "" && !isPtr
+}
+
+// structType returns the struct type declared as t, if any.
+func (g *generator) structType(t ast.Expr) (*ast.StructType, bool) {
+	for depth := 0; depth < 10; depth++ {
+		switch tt := t.(type) {
+		case *ast.ParenExpr:
+			t = tt.X
+		case *ast.Ident:
+			decl, ok := g.decls[tt.Name]
+			if !ok {
+				return nil, false
+			}
+			t = decl
+		case *ast.StructType:
+			return tt, true
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// annotated reports whether the struct has at least one annotated field,
+// including the ones of its flattened embedded structs.
+func (g *generator) annotated(st *ast.StructType, seen map[*ast.StructType]bool) bool {
+	if seen[st] {
+		return false
+	}
+	seen = with(seen, st)
+
+	for _, field := range st.Fields.List {
+		if _, ok := annotation(field); ok {
+			return true
+		}
+		if !flattened(field) {
+			continue
+		}
+		if est, ok := g.structType(field.Type); ok && g.annotated(est, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// with returns a copy of seen with st added.
+func with(seen map[*ast.StructType]bool, st *ast.StructType) map[*ast.StructType]bool {
+	m := make(map[*ast.StructType]bool, len(seen)+1)
+	for k := range seen {
+		m[k] = true
+	}
+	m[st] = true
+	return m
+}
+
+func (g *generator) generate(w *bytes.Buffer, s *structDecl) error {
+	recv := strings.ToLower(s.name[:1])
+	if recv == "v" {
+		recv = "x" // Avoid shadowing the package name.
+	}
+
+	entries, err := g.fields(recv, s.typ, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n// Schema returns the validation schema of %s.\n", s.name)
+	fmt.Fprintf(w, "func (%s %s) Schema() v.OrderedSchema {\n", recv, s.name)
+	if len(entries) == 0 {
+		fmt.Fprintf(w, "\treturn v.OrderedSchema{}\n}\n")
+		return nil
+	}
+	fmt.Fprintf(w, "\treturn v.OrderedSchema{}.\n")
+	for i, e := range entries {
+		if i < len(entries)-1 {
+			e += "."
+		}
+		fmt.Fprintf(w, "\t\t%s\n", e)
+	}
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
+
+// fields returns the schema entries (i.e. the arguments of Add) of the
+// fields of st, in declaration order, which are accessed via x.
+//
+// Embedded structs are flattened as in package vtag, thus their fields are
+// added in place. Since the fields of a struct declared in another package
+// are unknown, such an embedded struct must be either annotated or skipped
+// explicitly (by `validate:"-"`).
+func (g *generator) fields(x string, st *ast.StructType, seen map[*ast.StructType]bool) ([]string, error) {
+	seen = with(seen, st)
+
+	var entries []string
+	for _, field := range st.Fields.List {
+		rules, _ := annotation(field)
+		if rules == "-" {
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			name, ok := embeddedName(field.Type)
+			if !ok {
+				return nil, g.errorf(field, "unsupported embedded field %s", g.typeString(field.Type))
+			}
+			if flattened(field) {
+				est, ok := g.structType(field.Type)
+				switch {
+				case ok && seen[est]:
+					return nil, g.errorf(field, "invalid recursive embedded field %s", name)
+				case ok:
+					sub, err := g.fields(x+"."+name, est, seen)
+					if err != nil {
+						return nil, err
+					}
+					entries = append(entries, sub...)
+					continue
+				case g.kind(field.Type) == kindOther:
+					return nil, g.errorf(field, "embedded field %s: the fields of %s are unknown, annotate it or skip it by `validate:\"-\"`", name, g.typeString(field.Type))
+				}
+			}
+			// Otherwise, the embedded field is validated as a named one.
+			names = []*ast.Ident{ast.NewIdent(name)}
+		}
+
+		var parsed []vrule.Rule
+		if rules != "" {
+			var err error
+			if parsed, err = vrule.ParseRules(rules); err != nil {
+				return nil, g.errorf(field, "%v", err)
+			}
+		}
+
+		expr, err := g.validator(field.Type, parsed)
+		if err != nil {
+			return nil, g.errorf(field, "%v", err)
+		}
+		if expr == "" {
+			continue
+		}
+
+		for _, name := range names {
+			if !name.IsExported() {
+				continue
+			}
+			entries = append(entries, fmt.Sprintf("Add(v.F(%q, %s.%s), %s)", jsonName(field, name.Name), x, name.Name, expr))
+		}
+	}
+	return entries, nil
+}
+
+func (g *generator) errorf(field *ast.Field, format string, args ...any) error {
+	return fmt.Errorf("%s: %s", g.fset.Position(field.Pos()), fmt.Sprintf(format, args...))
+}
+
+// typeString returns the source code of the type expression.
+func (g *generator) typeString(t ast.Expr) string {
+	return types.ExprString(t)
+}
+
+// kinds of types.
+const (
+	kindOther = iota
+	kindBool
+	kindNumber
+	kindString
+	kindSlice
+	kindArray
+	kindMap
+	kindStruct
+	kindPointer
+)
+
+// kind returns the kind of type t, by resolving the declared types.
+func (g *generator) kind(t ast.Expr) int {
+	for depth := 0; depth < 10; depth++ {
+		switch tt := t.(type) {
+		case *ast.ParenExpr:
+			t = tt.X
+			continue
+		case *ast.StarExpr:
+			return kindPointer
+		case *ast.ArrayType:
+			if tt.Len == nil {
+				return kindSlice
+			}
+			return kindArray
+		case *ast.MapType:
+			return kindMap
+		case *ast.StructType:
+			return kindStruct
+		case *ast.Ident:
+			switch tt.Name {
+			case "bool":
+				return kindBool
+			case "string":
+				return kindString
+			case "int", "int8", "int16", "int32", "int64",
+				"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+				"float32", "float64", "byte", "rune":
+				return kindNumber
+			}
+			decl, ok := g.decls[tt.Name]
+			if !ok {
+				return kindOther
+			}
+			t = decl
+			continue
+		}
+		return kindOther
+	}
+	return kindOther
+}
+
+// basic returns the name of the underlying basic type of t, by resolving
+// the declared types. It returns an empty string if there is no such type.
+func (g *generator) basic(t ast.Expr) string {
+	for depth := 0; depth < 10; depth++ {
+		switch tt := t.(type) {
+		case *ast.ParenExpr:
+			t = tt.X
+		case *ast.Ident:
+			if obj, ok := types.Universe.Lookup(tt.Name).(*types.TypeName); ok {
+				if _, ok := obj.Type().(*types.Basic); ok {
+					return tt.Name
+				}
+			}
+			decl, ok := g.decls[tt.Name]
+			if !ok {
+				return ""
+			}
+			t = decl
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// elem returns the element type of the slice, array or map type t.
+func (g *generator) elem(t ast.Expr) ast.Expr {
+	for {
+		switch tt := t.(type) {
+		case *ast.ParenExpr:
+			t = tt.X
+		case *ast.ArrayType:
+			return tt.Elt
+		case *ast.MapType:
+			return tt.Value
+		case *ast.Ident:
+			t = g.decls[tt.Name]
+		default:
+			return nil
+		}
+	}
+}
+
+// validator returns the source code of the validator for values of type t
+// with the given rules, or an empty string if there is nothing to validate.
+func (g *generator) validator(t ast.Expr, rules []vrule.Rule) (string, error) {
+	var dive []vrule.Rule
+	hasDive := false
+	for i, r := range rules {
+		if r.Name == "dive" {
+			rules, dive, hasDive = rules[:i], rules[i+1:], true
+			break
+		}
+	}
+
+	omitEmpty := false
+	var filtered []vrule.Rule
+	for _, r := range rules {
+		if r.Name == "omitempty" {
+			omitEmpty = true
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	rules = filtered
+
+	kind := g.kind(t)
+	if kind == kindPointer {
+		return g.pointer(t, rules, dive, hasDive)
+	}
+	typ := g.typeString(t)
+
+	var parts []string
+	for _, r := range rules {
+		expr, err := g.rule(typ, g.basic(t), kind, r)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, expr)
+	}
+
+	switch {
+	case hasDive:
+		if kind != kindSlice && kind != kindMap {
+			return "", fmt.Errorf("dive: type %s is not a slice or a map", typ)
+		}
+		elem, err := g.validator(g.elem(t), dive)
+		if err != nil {
+			return "", err
+		}
+		if elem != "" {
+			parts = append(parts, g.each(typ, kind, elem))
+		}
+	default:
+		if nested := g.nested(t, typ, kind); nested != "" {
+			parts = append(parts, nested)
+		}
+	}
+
+	validator := all(parts)
+	if omitEmpty && validator != "" {
+		switch kind {
+		case kindSlice:
+			validator = fmt.Sprintf("v.Any(v.LenSlice[%s](0, 0), %s).LastError()", typ, validator)
+		case kindMap:
+			return "", fmt.Errorf("omitempty is not supported for maps")
+		default:
+			validator = fmt.Sprintf("v.ZeroOr[%s](%s)", typ, validator)
+		}
+	}
+	return validator, nil
+}
+
+// pointer returns the validator for values of pointer type t, which applies
+// the rules, except required, to the pointed values.
+func (g *generator) pointer(t ast.Expr, rules, dive []vrule.Rule, hasDive bool) (string, error) {
+	typ := g.typeString(t)
+
+	var parts []string
+	var rest []vrule.Rule
+	for _, r := range rules {
+		if r.Name == "required" || r.Name == "nonzero" {
+			parts = append(parts, fmt.Sprintf("v.Nonzero[%s]()", typ))
+			continue
+		}
+		rest = append(rest, r)
+	}
+	if hasDive {
+		rest = append(append(rest, vrule.Rule{Name: "dive"}), dive...)
+	}
+
+	elem := t.(*ast.StarExpr).X
+	if ident, ok := elem.(*ast.Ident); ok && g.targets[ident.Name] && len(rest) == 0 {
+		parts = append(parts, fmt.Sprintf("v.Nested(func(x %s) v.Validator {\nif x == nil {\nreturn v.Schema{}\n}\nreturn x.Schema()\n})", typ))
+		return all(parts), nil
+	}
+
+	validator, err := g.validator(elem, rest)
+	if err != nil {
+		return "", err
+	}
+	if validator != "" {
+		parts = append(parts, fmt.Sprintf("v.Nested(func(x %s) v.Validator {\nif x == nil {\nreturn v.Schema{}\n}\nreturn v.Value(*x, %s)\n})", typ, validator))
+	}
+	return all(parts), nil
+}
+
+// nested returns the validator for the annotated structs contained in
+// values of type t.
+func (g *generator) nested(t ast.Expr, typ string, kind int) string {
+	switch kind {
+	case kindStruct:
+		if ident, ok := t.(*ast.Ident); ok && g.targets[ident.Name] {
+			return fmt.Sprintf("v.Nested(func(x %s) v.Validator { return x.Schema() })", typ)
+		}
+	case kindSlice, kindMap:
+		elem := g.elem(t)
+		elemKind := g.kind(elem)
+		var validator string
+		if elemKind == kindPointer {
+			validator, _ = g.pointer(elem, nil, nil, false)
+		} else {
+			validator = g.nested(elem, g.typeString(elem), elemKind)
+		}
+		if validator != "" {
+			return g.each(typ, kind, validator)
+		}
+	}
+	return ""
+}
+
+// each returns the validator applying elem to each element of values of
+// the given slice or map type.
+func (g *generator) each(typ string, kind int, elem string) string {
+	if kind == kindMap {
+		return fmt.Sprintf("v.EachMap[%s](%s)", typ, elem)
+	}
+	return fmt.Sprintf("v.EachSlice[%s](%s)", typ, elem)
+}
+
+// rule returns the source code of the built-in validator for the rule, which
+// applies to values of type typ, whose underlying type is the basic type
+// named basic (if any).
+func (g *generator) rule(typ, basic string, kind int, r vrule.Rule) (string, error) {
+	wantArgs := func(n int) error {
+		if len(r.Args) != n {
+			return fmt.Errorf("rule %q: want %d arguments, got %d", r.Name, n, len(r.Args))
+		}
+		return nil
+	}
+	wantKind := func(kinds ...int) error {
+		for _, k := range kinds {
+			if kind == k {
+				return nil
+			}
+		}
+		return fmt.Errorf("rule %q: unsupported type %s", r.Name, typ)
+	}
+	literals := func() (string, error) {
+		lits := make([]string, len(r.Args))
+		for i, arg := range r.Args {
+			lit, err := literal(basic, kind, arg)
+			if err != nil {
+				return "", fmt.Errorf("rule %q: %v", r.Name, err)
+			}
+			lits[i] = lit
+		}
+		return strings.Join(lits, ", "), nil
+	}
+
+	switch r.Name {
+	case "required", "nonzero":
+		if err := wantArgs(0); err != nil {
+			return "", err
+		}
+		switch kind {
+		case kindSlice:
+			return fmt.Sprintf("v.LenSlice[%s](1, math.MaxInt)", typ), nil
+		case kindMap:
+			return fmt.Sprintf("v.Is(func(m %s) bool { return len(m) > 0 })", typ), nil
+		}
+		return fmt.Sprintf("v.Nonzero[%s]()", typ), nil
+	case "zero":
+		if err := wantArgs(0); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("v.Zero[%s]()", typ), nil
+	case "eq", "ne", "gt", "gte", "lt", "lte":
+		if err := wantArgs(1); err != nil {
+			return "", err
+		}
+		if r.Name == "eq" || r.Name == "ne" {
+			if err := wantKind(kindBool, kindNumber, kindString); err != nil {
+				return "", err
+			}
+		} else if err := wantKind(kindNumber, kindString); err != nil {
+			return "", err
+		}
+		lits, err := literals()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("v.%s[%s](%s)", exported(r.Name), typ, lits), nil
+	case "range":
+		if err := wantArgs(2); err != nil {
+			return "", err
+		}
+		if err := wantKind(kindNumber, kindString); err != nil {
+			return "", err
+		}
+		lits, err := literals()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("v.Range[%s](%s)", typ, lits), nil
+	case "in", "nin":
+		if len(r.Args) == 0 {
+			return "", fmt.Errorf("rule %q: want at least 1 argument, got 0", r.Name)
+		}
+		if err := wantKind(kindBool, kindNumber, kindString); err != nil {
+			return "", err
+		}
+		lits, err := literals()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("v.%s[%s](%s)", exported(r.Name), typ, lits), nil
+	case "len_string", "rune_count", "len_slice":
+		if err := wantArgs(2); err != nil {
+			return "", err
+		}
+		want := kindString
+		if r.Name == "len_slice" {
+			want = kindSlice
+		}
+		if err := wantKind(want); err != nil {
+			return "", err
+		}
+		min := strings.TrimSpace(r.Args[0])
+		max := strings.TrimSpace(r.Args[1])
+		if _, err := strconv.Atoi(min); err != nil {
+			return "", fmt.Errorf("rule %q: invalid min %q", r.Name, min)
+		}
+		if max == "" {
+			max = "math.MaxInt"
+		} else if _, err := strconv.Atoi(max); err != nil {
+			return "", fmt.Errorf("rule %q: invalid max %q", r.Name, max)
+		}
+		switch r.Name {
+		case "len_string":
+			return asString(typ, fmt.Sprintf("v.LenString(%s, %s)", min, max)), nil
+		case "rune_count":
+			return asString(typ, fmt.Sprintf("v.RuneCount(%s, %s)", min, max)), nil
+		default:
+			return fmt.Sprintf("v.LenSlice[%s](%s, %s)", typ, min, max), nil
+		}
+	case "match":
+		if err := wantKind(kindString); err != nil {
+			return "", err
+		}
+		pattern := strings.Join(r.Args, ",")
+		lit := "`" + pattern + "`"
+		if strings.Contains(pattern, "`") {
+			lit = strconv.Quote(pattern)
+		}
+		return asString(typ, fmt.Sprintf("v.Match(%s)", lit)), nil
+	default:
+		return "", fmt.Errorf("unknown rule %q", r.Name)
+	}
+}
+
+// asString returns the validator applying the string validator to values
+// of type typ, whose underlying type is string. Since LenString, RuneCount
+// and Match only support string values, values of named types are converted
+// to string first.
+func asString(typ, validator string) string {
+	if typ == "string" {
+		return validator
+	}
+	return fmt.Sprintf("v.Nested(func(x %s) v.Validator { return v.Value(string(x), %s) })", typ, validator)
+}
+
+// literal returns the Go literal of the rule argument for values of the
+// given kind, whose underlying type is the basic type named basic. Numbers
+// are checked against basic, so that the generated code always compiles.
+func literal(basic string, kind int, arg string) (string, error) {
+	switch kind {
+	case kindString:
+		return strconv.Quote(vrule.Unescape(arg)), nil
+	case kindBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(arg))
+		if err != nil {
+			return "", fmt.Errorf("invalid boolean %q", arg)
+		}
+		return strconv.FormatBool(b), nil
+	default:
+		s := strings.TrimSpace(arg)
+		if !isNumber(s) {
+			return "", fmt.Errorf("invalid number %q", arg)
+		}
+		// Let the type checker tell whether the number is representable
+		// by the basic type (e.g. 0.5 for int, or 300 for uint8).
+		if _, err := types.Eval(token.NewFileSet(), nil, token.NoPos, basic+"("+s+")"); err != nil {
+			msg := err.Error()
+			if i := strings.Index(msg, ": "); i >= 0 {
+				msg = msg[i+2:] // Strip the position.
+			}
+			return "", fmt.Errorf("invalid %s %q: %s", basic, arg, msg)
+		}
+		return s, nil
+	}
+}
+
+// isNumber reports whether s is a (signed) Go number literal.
+func isNumber(s string) bool {
+	expr, err := parser.ParseExpr(s)
+	if err != nil {
+		return false
+	}
+	if u, ok := expr.(*ast.UnaryExpr); ok && (u.Op == token.SUB || u.Op == token.ADD) {
+		expr = u.X
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && (lit.Kind == token.INT || lit.Kind == token.FLOAT)
+}
+
+// exported returns the name of the built-in validator factory for the rule.
+func exported(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func all(parts []string) string {
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	default:
+		return "v.All(\n" + strings.Join(parts, ",\n") + ",\n)"
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	got, err := Generate([]string{"testdata/user.go"})
+	if err != nil {
+		t.Fatalf("Generate err: %v", err)
+	}
+
+	golden := "testdata/user_validating.go.golden"
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+// schemaTest validates sample values by using the generated Schema methods.
+const schemaTest = `package user
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestSchema(t *testing.T) {
+	age := 1
+	u := User{
+		Base:     Base{ID: 1},
+		Name:     "abc",
+		Level:    1,
+		Age:      &age,
+		Nick:     "bcde",
+		Aliases:  []Name{"ab", "abc"},
+		Birthday: time.Now(),
+		Contacts: map[string]*Address{"a": {Country: "X", City: "B,C"}},
+	}
+
+	var got []string
+	for _, err := range v.Validate(u.Schema()) {
+		got = append(got, err.Field()+": "+err.Kind()+"("+err.Message()+")")
+	}
+	want := []string{
+		"nick: INVALID(has an invalid length)",
+		"aliases[1]: INVALID(the number of runes is not between the given range)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got (%v) != Want (%v)", got, want)
+	}
+}
+`
+
+// TestGenerate_Compile compiles the generated code, along with the source,
+// and runs schemaTest against it.
+func TestGenerate_Compile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	got, err := Generate([]string{"testdata/user.go"})
+	if err != nil {
+		t.Fatalf("Generate err: %v", err)
+	}
+
+	// The package must be inside the module to import validating, and
+	// the leading underscore keeps it out of ./... patterns.
+	dir, err := os.MkdirTemp(".", "_compile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src, err := os.ReadFile("testdata/user.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"user.go":            src,
+		"user_validating.go": got,
+		"user_test.go":       []byte(schemaTest),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := exec.Command(goBin, "test", "./"+filepath.Base(dir)).CombinedOutput()
+	if err != nil {
+		t.Errorf("go test err: %v\n%s", err, out)
+	}
+}
+
+func TestGenerate_Error(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "unknown rule",
+			src:  "package p\ntype T struct {\n\tA string `validate:\"foo\"`\n}\n",
+			want: `p.go:3:2: unknown rule "foo"`,
+		},
+		{
+			name: "unsupported type",
+			src:  "package p\ntype T struct {\n\tA bool `validate:\"gte(1)\"`\n}\n",
+			want: `p.go:3:2: rule "gte": unsupported type bool`,
+		},
+		{
+			name: "invalid number",
+			src:  "package p\ntype T struct {\n\t//validating:in(1,a)\n\tA int\n}\n",
+			want: `p.go:4:2: rule "in": invalid number "a"`,
+		},
+		{
+			name: "truncated number",
+			src:  "package p\ntype T struct {\n\tA int `validate:\"gte(0.5)\"`\n}\n",
+			want: `p.go:3:2: rule "gte": invalid int "0.5": cannot convert 0.5 (untyped float constant) to type int`,
+		},
+		{
+			name: "overflowed number",
+			src:  "package p\ntype Level uint8\ntype T struct {\n\tA Level `validate:\"lte(300)\"`\n}\n",
+			want: `p.go:4:2: rule "lte": invalid uint8 "300": constant 300 overflows uint8`,
+		},
+		{
+			name: "non-literal number",
+			src:  "package p\ntype T struct {\n\tA float64 `validate:\"in(1,1+1)\"`\n}\n",
+			want: `p.go:3:2: rule "in": invalid number "1+1"`,
+		},
+		{
+			name: "embedded struct of another package",
+			src:  "package p\nimport \"time\"\ntype T struct {\n\ttime.Time\n\tA int `validate:\"gte(1)\"`\n}\n",
+			want: "p.go:4:2: embedded field Time: the fields of time.Time are unknown, annotate it or skip it by `validate:\"-\"`",
+		},
+		{
+			name: "invalid syntax",
+			src:  "package p\ntype T struct {\n\tA int `validate:\"gte(1\"`\n}\n",
+			want: `p.go:3:2: vrule: "gte(1": syntax error: unbalanced parentheses`,
+		},
+		{
+			name: "dive on non-container",
+			src:  "package p\ntype T struct {\n\tA int `validate:\"dive,gte(1)\"`\n}\n",
+			want: `p.go:3:2: dive: type int is not a slice or a map`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "p.go")
+			if err := os.WriteFile(file, []byte(c.src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Generate([]string{file})
+			if err == nil {
+				t.Fatalf("Got (nil) != Want (%s)", c.want)
+			}
+			if got := strings.TrimPrefix(err.Error(), dir+string(filepath.Separator)); got != c.want {
+				t.Errorf("Got (%s) != Want (%s)", err, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Command validating-gen generates Schema methods for annotated structs,
+// which provides type-safe validation without reflection at runtime.
+//
+// The rules of a field are specified, by using the syntax of package vrule,
+// either in the `validate` tag or in the `//validating:` comments of the
+// field, and the special tokens `omitempty` and `dive` are supported as in
+// package vtag:
+//
+//	type User struct {
+//		Name string   `json:"name" validate:"required,len_string(1,64)"`
+//		Tags []string `json:"tags" validate:"dive,len_string(1,20)"`
+//
+//		//validating:omitempty,match(@)
+//		Email string `json:"email"`
+//	}
+//
+// A Schema method, which returns a v.OrderedSchema of the fields in declaration
+// order, is generated for each struct having at least one annotated field.
+// Fields whose types are such structs (also via pointers, slices and maps)
+// are validated by their Schema methods as well. Embedded structs are
+// flattened as in package vtag.
+//
+// The arguments of the rules are checked against the types of the fields
+// (e.g. gte(0.5) is rejected for an int field), and any error is reported
+// along with the position of the field.
+//
+// Usage:
+//
+//	validating-gen [-output file] [file.go ...]
+//
+// If no file is given, $GOFILE is used, which makes it work with go generate:
+//
+//	//go:generate go run github.com/RussellLuo/validating/v3/cmd/validating-gen
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	output := flag.String("output", "", "output file name; default is <first file>_validating.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: validating-gen [-output file] [file.go ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		if f := os.Getenv("GOFILE"); f != "" {
+			files = []string{f}
+		}
+	}
+	if len(files) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *output == "" {
+		*output = strings.TrimSuffix(files[0], ".go") + "_validating.go"
+	}
+
+	src, err := Generate(files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validating-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "validating-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
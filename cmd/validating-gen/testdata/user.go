@@ -0,0 +1,50 @@
+package user
+
+import "time"
+
+type Level int
+
+type Address struct {
+	Country string `json:"country" validate:"required"`
+	City    string `json:"city" validate:"in(A, B\\,C)"`
+}
+
+type Addresses []Address
+
+type Name string
+
+type Base struct {
+	ID uint8 `json:"id" validate:"gte(1)"`
+}
+
+type User struct {
+	Base
+
+	Name  string `json:"name,omitempty" validate:"required,len_string(1,64),match(^[a-z]{1,3}$)"`
+	Level Level  `json:"level" validate:"range(1,10)"`
+	Age   *int   `json:"age" validate:"required,gte(0)"`
+
+	//validating:omitempty
+	//validating:match(@)
+	Email string `json:"email"`
+
+	Nick    Name   `json:"nick" validate:"omitempty,len_string(1,3),match(^a)"`
+	Aliases []Name `json:"aliases" validate:"dive,rune_count(1,2)"`
+
+	Tags     []string           `json:"tags" validate:"len_slice(0,3),dive,len_string(1,)"`
+	Scores   map[string]float64 `json:"scores" validate:"dive,gte(0.5)"`
+	Admin    bool               `validate:"eq(false)"`
+	Birthday time.Time          `validate:"nonzero"`
+
+	Address  *Address
+	Backups  Addresses
+	Contacts map[string]*Address `validate:"required"`
+	Ignored  Address             `validate:"-"`
+	Created  time.Time
+	internal string `validate:"required"`
+}
+
+// Plain has no annotated fields.
+type Plain struct {
+	Name string
+}
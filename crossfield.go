@@ -0,0 +1,108 @@
+package validating
+
+import (
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// EqField is a leaf validator factory used to create a validator, which will
+// succeed when the field's value equals the value of the other field (e.g.
+// the confirmation of a password).
+//
+// The name of the other field is available as the param "field", which
+// can be referenced by the message as "{field}".
+func EqField[T comparable](other *Field) *MessageValidator {
+	return compareField("EqField", "eq_field", "does not equal {field}", other, func(v, o T) bool {
+		return v == o
+	})
+}
+
+// NeField is a leaf validator factory used to create a validator, which will
+// succeed when the field's value does not equal the value of the other field.
+func NeField[T comparable](other *Field) *MessageValidator {
+	return compareField("NeField", "ne_field", "equals {field}", other, func(v, o T) bool {
+		return v != o
+	})
+}
+
+// GtField is a leaf validator factory used to create a validator, which will
+// succeed when the field's value is greater than the value of the other field.
+func GtField[T constraints.Ordered](other *Field) *MessageValidator {
+	return compareField("GtField", "gt_field", "is lower than or equal to {field}", other, func(v, o T) bool {
+		return v > o
+	})
+}
+
+// GteField is a leaf validator factory used to create a validator, which will
+// succeed when the field's value is greater than or equal to the value of the
+// other field (e.g. a maximum price compared with a minimum one).
+func GteField[T constraints.Ordered](other *Field) *MessageValidator {
+	return compareField("GteField", "gte_field", "is lower than {field}", other, func(v, o T) bool {
+		return v >= o
+	})
+}
+
+// LtField is a leaf validator factory used to create a validator, which will
+// succeed when the field's value is lower than the value of the other field.
+func LtField[T constraints.Ordered](other *Field) *MessageValidator {
+	return compareField("LtField", "lt_field", "is greater than or equal to {field}", other, func(v, o T) bool {
+		return v < o
+	})
+}
+
+// LteField is a leaf validator factory used to create a validator, which will
+// succeed when the field's value is lower than or equal to the value of the
+// other field.
+func LteField[T constraints.Ordered](other *Field) *MessageValidator {
+	return compareField("LteField", "lte_field", "is greater than {field}", other, func(v, o T) bool {
+		return v <= o
+	})
+}
+
+// BeforeField is a leaf validator factory used to create a validator, which
+// will succeed when the field's value (of type time.Time) is before the value
+// of the other field.
+func BeforeField(other *Field) *MessageValidator {
+	return compareField("BeforeField", "before_field", "is not before {field}", other, func(v, o time.Time) bool {
+		return v.Before(o)
+	})
+}
+
+// AfterField is a leaf validator factory used to create a validator, which
+// will succeed when the field's value (of type time.Time) is after the value
+// of the other field (e.g. an end date compared with a start date).
+func AfterField(other *Field) *MessageValidator {
+	return compareField("AfterField", "after_field", "is not after {field}", other, func(v, o time.Time) bool {
+		return v.After(o)
+	})
+}
+
+// compareField creates a validator, which succeeds when valid reports true
+// for the field's value and the value of the other field.
+func compareField[T any](name, code, message string, other *Field, valid func(v, o T) bool) (mv *MessageValidator) {
+	mv = &MessageValidator{
+		Message: message,
+		Code:    code,
+		Params:  map[string]any{"field": other.Name},
+		Validator: Func(func(field *Field) Errors {
+			var want T
+			v, ok := field.Value.(T)
+			if !ok {
+				return NewUnsupportedErrors(name, field, want)
+			}
+			o, ok := other.Value.(T)
+			if !ok {
+				// Report on the field being validated, whose name is the
+				// full path (unlike the name of the other field).
+				return NewUnsupportedErrors(name, &Field{Name: field.Name, Value: other.Value}, want)
+			}
+
+			if !valid(v, o) {
+				return mv.Invalid(field)
+			}
+			return nil
+		}),
+	}
+	return
+}
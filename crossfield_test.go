@@ -0,0 +1,100 @@
+package validating_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestCrossField(t *testing.T) {
+	type Form struct {
+		Password        string
+		PasswordConfirm string
+		MinPrice        float64
+		MaxPrice        float64
+		StartDate       time.Time
+		EndDate         time.Time
+	}
+	schema := func(f Form) v.Validator {
+		password := v.F("password", f.Password)
+		minPrice := v.F("min_price", f.MinPrice)
+		startDate := v.F("start_date", f.StartDate)
+		return v.Schema{
+			password: v.Nonzero[string](),
+			v.F("password_confirm", f.PasswordConfirm): v.EqField[string](password),
+			minPrice:                     v.Gte(0.0),
+			v.F("max_price", f.MaxPrice): v.GteField[float64](minPrice),
+			startDate:                    v.After(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			v.F("end_date", f.EndDate):   v.AfterField(startDate),
+		}
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		form Form
+		errs v.Errors
+	}{
+		{
+			form: Form{Password: "x", PasswordConfirm: "x", MinPrice: 1, MaxPrice: 1, StartDate: start, EndDate: start.Add(time.Hour)},
+			errs: nil,
+		},
+		{
+			form: Form{Password: "x", PasswordConfirm: "y", MinPrice: 2, MaxPrice: 1, StartDate: start, EndDate: start},
+			errs: v.Errors{
+				v.NewCodedError("form.password_confirm", v.ErrInvalid, "does not equal password", "eq_field", map[string]any{"field": "password"}),
+				v.NewCodedError("form.max_price", v.ErrInvalid, "is lower than min_price", "gte_field", map[string]any{"field": "min_price"}),
+				v.NewCodedError("form.end_date", v.ErrInvalid, "is not after start_date", "after_field", map[string]any{"field": "start_date"}),
+			},
+		},
+	}
+	for _, c := range cases {
+		errs := v.Validate(v.Schema{v.F("form", c.form): v.Nested(schema)})
+		if !reflect.DeepEqual(errs.Map(), c.errs.Map()) {
+			t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+		}
+	}
+}
+
+func TestCrossField_Compare(t *testing.T) {
+	a, b := v.F("a", 1), v.F("b", 2)
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Second)
+
+	cases := []struct {
+		name      string
+		field     *v.Field
+		validator v.Validator
+		errs      v.Errors
+	}{
+		{"eq ok", a, v.EqField[int](v.F("o", 1)), nil},
+		{"eq", a, v.EqField[int](b), v.NewErrors("a", v.ErrInvalid, "does not equal b")},
+		{"ne ok", a, v.NeField[int](b), nil},
+		{"ne", a, v.NeField[int](v.F("o", 1)), v.NewErrors("a", v.ErrInvalid, "equals o")},
+		{"gt ok", b, v.GtField[int](a), nil},
+		{"gt", a, v.GtField[int](v.F("o", 1)), v.NewErrors("a", v.ErrInvalid, "is lower than or equal to o")},
+		{"gte ok", a, v.GteField[int](v.F("o", 1)), nil},
+		{"gte", a, v.GteField[int](b), v.NewErrors("a", v.ErrInvalid, "is lower than b")},
+		{"lt ok", a, v.LtField[int](b), nil},
+		{"lt", a, v.LtField[int](v.F("o", 1)), v.NewErrors("a", v.ErrInvalid, "is greater than or equal to o")},
+		{"lte ok", a, v.LteField[int](v.F("o", 1)), nil},
+		{"lte", b, v.LteField[int](a), v.NewErrors("b", v.ErrInvalid, "is greater than a")},
+		{"before ok", v.F("t", t1), v.BeforeField(v.F("o", t2)), nil},
+		{"before", v.F("t", t2), v.BeforeField(v.F("o", t2)), v.NewErrors("t", v.ErrInvalid, "is not before o")},
+		{"after", v.F("t", t1), v.AfterField(v.F("o", t2)), v.NewErrors("t", v.ErrInvalid, "is not after o")},
+		{"before const", v.F("t", t2), v.Before(t1), v.NewErrors("t", v.ErrInvalid, "is not before the given time")},
+		{"after const", v.F("t", t2), v.After(t1), nil},
+		{"custom message", a, v.EqField[int](b).Msg("must equal {field} ({value})"), v.NewErrors("a", v.ErrInvalid, "must equal b (1)")},
+		{"unsupported", v.F("a", "1"), v.EqField[int](b), v.NewErrors("a", v.ErrUnsupported, "EqField expected int but got string")},
+		{"unsupported other", a, v.EqField[int](v.F("o", "1")), v.NewErrors("a", v.ErrUnsupported, "EqField expected int but got string")},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := plainErrs(v.Validate(v.Schema{c.field: c.validator}))
+			if !reflect.DeepEqual(errs, c.errs) {
+				t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package validating
+
+import (
+	"sort"
+)
+
+// Description describes the rules enforced by a validator, which is
+// intended for introspection (e.g. exporting a schema as JSON Schema).
+type Description struct {
+	// Code identifies the rule. For leaf validators, it's the same as the
+	// code of the reported errors (e.g. "len_string"). For composite
+	// validators, it's the snake-cased name of the factory (e.g. "all",
+	// "each_slice"), or "schema" for Schema and OrderedSchema. An empty
+	// code means the validator is opaque (e.g. Func).
+	Code string
+
+	// Params holds the parameters of the rule.
+	Params map[string]any
+
+	// Fields holds the fields of a schema, in the validation order (or
+	// in the order of the field names for Schema).
+	Fields []FieldDescription
+
+	// Validators holds the descriptions of the sub-validators.
+	Validators []Description
+
+	// Apply describes the validator derived from the given value, for
+	// validators whose rules depend on the field's value (e.g. Nested).
+	// It reports false if the value is not supported.
+	Apply func(value any) (Description, bool)
+}
+
+// FieldDescription describes a field of a schema.
+type FieldDescription struct {
+	Name        string
+	Value       any
+	Description Description
+}
+
+// Describer is implemented by validators that can describe themselves.
+type Describer interface {
+	Describe() Description
+}
+
+// Describe returns the description of the given validator. Validators not
+// implementing Describer are described as opaque ones.
+func Describe(validator Validator) Description {
+	if d, ok := validator.(Describer); ok {
+		return d.Describe()
+	}
+	return Description{}
+}
+
+// WithDescription returns a validator, which delegates the actual validation
+// to validator, but describes itself as desc.
+//
+// It's mainly used to describe opaque validators, such as Func and Is.
+func WithDescription(validator Validator, desc Description) Validator {
+	return describedValidator{Validator: validator, desc: desc}
+}
+
+type describedValidator struct {
+	Validator
+	desc Description
+}
+
+func (dv describedValidator) Validate(field *Field) Errors {
+	return validate(dv.Validator, field)
+}
+
+func (dv describedValidator) Describe() Description {
+	return dv.desc
+}
+
+// describedFunc is a Func, which can describe itself.
+type describedFunc struct {
+	Func
+	describe func() Description
+}
+
+func (df describedFunc) Describe() Description {
+	return df.describe()
+}
+
+// Describe implements Describer.
+func (s Schema) Describe() Description {
+	desc := describeSchema(s)
+	sort.SliceStable(desc.Fields, func(i, j int) bool {
+		return desc.Fields[i].Name < desc.Fields[j].Name
+	})
+	return desc
+}
+
+// Describe implements Describer.
+func (s OrderedSchema) Describe() Description {
+	return describeSchema(s)
+}
+
+// Describe implements Describer.
+func (mv *MessageValidator) Describe() Description {
+	return Description{
+		Code:       mv.Code,
+		Params:     mv.Params,
+		Validators: describeAll(mv.validators...),
+	}
+}
+
+// Describe implements Describer. Always is transparent for introspection,
+// hence the description is the one of the underlying validator.
+func (av alwaysValidator) Describe() Description {
+	return Describe(av.Validator)
+}
+
+// Describe implements Describer.
+func (av *AnyValidator) Describe() Description {
+	return Description{Code: "any", Validators: describeAll(av.validators...)}
+}
+
+// Describe implements Describer. The first validator of the description is
+// the one used when the condition holds, and the second one (if any) is the
+// one set by Else. The description of the condition, which defaults to
+// UnspecifiedCondition, is kept in the param "condition".
+func (cv *ConditionalValidator) Describe() Description {
+	condition := cv.condition
+	switch {
+	case condition == "":
+		condition = UnspecifiedCondition
+	case cv.negated:
+		condition = "not (" + condition + ")"
+	}
+	desc := Description{
+		Code:       "when",
+		Params:     map[string]any{"condition": condition},
+		Validators: describeAll(cv.then),
+	}
+	if cv.els != nil {
+		desc.Validators = append(desc.Validators, Describe(cv.els))
+	}
+	return desc
+}
+
+// Describe implements Describer.
+func (ov *ObjectValidator) Describe() Description {
+	desc := Description{Code: "object", Params: map[string]any{}}
+
+	var required []string
+	for _, p := range ov.props {
+		desc.Fields = append(desc.Fields, FieldDescription{
+			Name:        p.Key,
+			Description: Describe(p.Validator),
+		})
+		if p.required {
+			required = append(required, p.Key)
+		}
+	}
+
+	if len(required) > 0 {
+		desc.Params["required"] = required
+	}
+	if ov.strict {
+		desc.Params["strict"] = true
+	}
+	if ov.additional != nil {
+		desc.Validators = describeAll(ov.additional)
+	}
+	return desc
+}
+
+func describeSchema(s schema) Description {
+	desc := Description{Code: "schema"}
+	s.each(func(field *Field, validator Validator) bool {
+		desc.Fields = append(desc.Fields, FieldDescription{
+			Name:        field.Name,
+			Value:       field.Value,
+			Description: Describe(validator),
+		})
+		return true
+	})
+	return desc
+}
+
+// describeAll returns the descriptions of the given non-nil validators.
+func describeAll(validators ...Validator) []Description {
+	var descs []Description
+	for _, v := range validators {
+		if v != nil {
+			descs = append(descs, Describe(v))
+		}
+	}
+	return descs
+}
+
+// describeApplied describes the validator returned by f, which reports
+// false if f panics (e.g. f dereferences a nil pointer).
+func describeApplied(f func() Validator) (desc Description, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			desc, ok = Description{}, false
+		}
+	}()
+	return Describe(f()), true
+}
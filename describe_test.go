@@ -0,0 +1,157 @@
+package validating_test
+
+import (
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func isDraft() bool { return true }
+
+func TestDescribe(t *testing.T) {
+	type Phone struct {
+		Number string
+	}
+
+	cases := []struct {
+		name      string
+		validator v.Validator
+		want      v.Description
+	}{
+		{
+			name:      "opaque",
+			validator: v.Func(func(field *v.Field) v.Errors { return nil }),
+			want:      v.Description{},
+		},
+		{
+			name:      "leaf",
+			validator: v.LenString(1, 5),
+			want:      v.Description{Code: "len_string", Params: map[string]any{"min": 1, "max": 5}},
+		},
+		{
+			name:      "composite",
+			validator: v.All(v.Not(v.In(1, 2)), v.Any(v.Zero[int](), v.Gte(10))),
+			want: v.Description{Code: "all", Validators: []v.Description{
+				{Code: "not", Validators: []v.Description{
+					{Code: "in", Params: map[string]any{"values": []any{1, 2}}},
+				}},
+				{Code: "any", Validators: []v.Description{
+					{Code: "zero"},
+					{Code: "gte", Params: map[string]any{"gte": 10}},
+				}},
+			}},
+		},
+		{
+			name: "schema",
+			validator: v.Schema{
+				v.F("b", 0):  v.Nonzero[int](),
+				v.F("a", ""): v.EachSlice[[]string](v.Nonzero[string]()),
+			},
+			want: v.Description{Code: "schema", Fields: []v.FieldDescription{
+				{Name: "a", Value: "", Description: v.Description{Code: "each_slice", Validators: []v.Description{{Code: "nonzero"}}}},
+				{Name: "b", Value: 0, Description: v.Description{Code: "nonzero"}},
+			}},
+		},
+		{
+			name:      "conditional",
+			validator: v.When(true, v.Nonzero[string]()).Else(v.Zero[string]()).Cond(`type is "company"`),
+			want: v.Description{
+				Code:   "when",
+				Params: map[string]any{"condition": `type is "company"`},
+				Validators: []v.Description{
+					{Code: "nonzero"},
+					{Code: "zero"},
+				},
+			},
+		},
+		{
+			name:      "conditional negated",
+			validator: v.Unless(true, v.Nonzero[string]()).Cond("draft"),
+			want: v.Description{
+				Code:       "when",
+				Params:     map[string]any{"condition": "not (draft)"},
+				Validators: []v.Description{{Code: "nonzero"}},
+			},
+		},
+		{
+			name:      "conditional without description",
+			validator: v.Unless(true, v.Nonzero[string]()),
+			want: v.Description{
+				Code:       "when",
+				Params:     map[string]any{"condition": v.UnspecifiedCondition},
+				Validators: []v.Description{{Code: "nonzero"}},
+			},
+		},
+		{
+			name:      "conditional by named function",
+			validator: v.If(isDraft, v.Nonzero[string]()),
+			want: v.Description{
+				Code:       "when",
+				Params:     map[string]any{"condition": "isDraft"},
+				Validators: []v.Description{{Code: "nonzero"}},
+			},
+		},
+		{
+			name:      "conditional by anonymous function",
+			validator: v.If(func() bool { return true }, v.Nonzero[string]()),
+			want: v.Description{
+				Code:       "when",
+				Params:     map[string]any{"condition": v.UnspecifiedCondition},
+				Validators: []v.Description{{Code: "nonzero"}},
+			},
+		},
+		{
+			name:      "required",
+			validator: v.Required[int](v.Gte(1)),
+			want: v.Description{
+				Code:       "required",
+				Validators: []v.Description{{Code: "gte", Params: map[string]any{"gte": 1}}},
+			},
+		},
+		{
+			name:      "always",
+			validator: v.Always(v.Nonzero[int]()),
+			want:      v.Description{Code: "nonzero"},
+		},
+		{
+			name:      "optional",
+			validator: v.Optional[string](),
+			want:      v.Description{Code: "optional"},
+		},
+		{
+			name: "custom description",
+			validator: v.WithDescription(v.Func(func(field *v.Field) v.Errors { return nil }), v.Description{
+				Code: "custom",
+			}),
+			want: v.Description{Code: "custom"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := v.Describe(c.validator)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", got, c.want)
+			}
+		})
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		nested := v.Nested(func(p *Phone) v.Validator {
+			return v.Schema{v.F("number", p.Number): v.Nonzero[string]()}
+		})
+
+		got := v.Describe(nested)
+		if got.Code != "nested" || len(got.Validators) != 0 {
+			t.Fatalf("Got (%+v) != Want (an unapplied nested description)", got)
+		}
+
+		applied, ok := got.Apply(&Phone{})
+		want := v.Description{Code: "schema", Fields: []v.FieldDescription{
+			{Name: "number", Value: "", Description: v.Description{Code: "nonzero"}},
+		}}
+		if !ok || !reflect.DeepEqual(applied, want) {
+			t.Errorf("Got (%+v) != Want (%+v)", applied, want)
+		}
+	})
+}
@@ -1,13 +1,15 @@
 package validating
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
 
 const (
-	ErrUnsupported = "UNSUPPORTED" // errors reported to developers. (panic is more appropriate?)
+	ErrUnsupported = "UNSUPPORTED" // errors reported to developers. (see Check and WithPanicOnUnsupported)
 	ErrInvalid     = "INVALID"     // errors reported to users.
+	ErrCanceled    = "CANCELED"    // errors reported when the validation is stopped by its context.
 )
 
 type Error interface {
@@ -17,6 +19,16 @@ type Error interface {
 	Message() string
 }
 
+// CodedError is an optional interface for Error, which provides a stable
+// machine-readable code and the parameters of the failed rule.
+//
+// All errors reported by the built-in leaf validators implement CodedError.
+type CodedError interface {
+	Error
+	Code() string
+	Params() map[string]any
+}
+
 type Errors []Error
 
 func NewErrors(field, kind, message string) Errors {
@@ -52,6 +64,64 @@ func (e Errors) Error() string {
 	return strings.Join(strs, ", ")
 }
 
+// MarshalJSON implements json.Marshaler. Each error is encoded as
+// a JSON object with the keys "field", "kind", "message", "code"
+// and "params" (the latter two are omitted if empty).
+func (e Errors) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+
+	errs := make([]jsonError, len(e))
+	for i, err := range e {
+		errs[i] = toJSONError(err)
+	}
+	return json.Marshal(errs)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Note that numbers in params are decoded as float64, as per
+// encoding/json.
+func (e *Errors) UnmarshalJSON(data []byte) error {
+	var errs []jsonError
+	if err := json.Unmarshal(data, &errs); err != nil {
+		return err
+	}
+	if errs == nil {
+		*e = nil
+		return nil
+	}
+
+	*e = make(Errors, len(errs))
+	for i, err := range errs {
+		(*e)[i] = err.toError()
+	}
+	return nil
+}
+
+// filter returns the errors of the given kind.
+func (e Errors) filter(kind string) (errs Errors) {
+	for _, err := range e {
+		if err.Kind() == kind {
+			errs.Append(err)
+		}
+	}
+	return
+}
+
+// UnsupportedError holds the errors of kind ErrUnsupported, which indicate
+// programming mistakes (e.g. a validator applied to a value of an unexpected
+// type) rather than invalid user input. It's returned by Check, and is the
+// value passed to panic when WithPanicOnUnsupported is set.
+type UnsupportedError struct {
+	Errors Errors
+}
+
+func (e *UnsupportedError) Error() string {
+	return "validating: unsupported: " + e.Errors.Error()
+}
+
 // Map converts the given errors to a map[string]Error, where the keys
 // of the map are the field names.
 func (e Errors) Map() map[string]Error {
@@ -69,10 +139,17 @@ type errorImpl struct {
 	field   string
 	kind    string
 	message string
+	code    string
+	params  map[string]any
 }
 
 func NewError(field, kind, message string) Error {
-	return errorImpl{field, kind, message}
+	return errorImpl{field: field, kind: kind, message: message}
+}
+
+// NewCodedError creates an error, which also implements CodedError.
+func NewCodedError(field, kind, message, code string, params map[string]any) Error {
+	return errorImpl{field: field, kind: kind, message: message, code: code, params: params}
 }
 
 func (e errorImpl) Field() string {
@@ -87,6 +164,29 @@ func (e errorImpl) Message() string {
 	return e.message
 }
 
+func (e errorImpl) Code() string {
+	return e.code
+}
+
+func (e errorImpl) Params() map[string]any {
+	return e.params
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e errorImpl) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(e))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *errorImpl) UnmarshalJSON(data []byte) error {
+	var je jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+	*e = je.toError()
+	return nil
+}
+
 func (e errorImpl) Error() string {
 	s := fmt.Sprintf("%s(%s)", e.kind, e.message)
 	if e.field == "" {
@@ -94,3 +194,35 @@ func (e errorImpl) Error() string {
 	}
 	return fmt.Sprintf("%s: %s", e.field, s)
 }
+
+// jsonError is the JSON representation of Error.
+type jsonError struct {
+	Field   string         `json:"field"`
+	Kind    string         `json:"kind"`
+	Message string         `json:"message"`
+	Code    string         `json:"code,omitempty"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+func toJSONError(err Error) jsonError {
+	je := jsonError{
+		Field:   err.Field(),
+		Kind:    err.Kind(),
+		Message: err.Message(),
+	}
+	if ce, ok := err.(CodedError); ok {
+		je.Code = ce.Code()
+		je.Params = ce.Params()
+	}
+	return je
+}
+
+func (je jsonError) toError() errorImpl {
+	return errorImpl{
+		field:   je.Field,
+		kind:    je.Kind,
+		message: je.Message,
+		code:    je.Code,
+		params:  je.Params,
+	}
+}
@@ -0,0 +1,65 @@
+package validating_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestErrors_JSON(t *testing.T) {
+	errs := v.Validate(v.OrderedSchema{}.
+		Add(v.F("name", ""), v.LenString(1, 5)).
+		Add(v.F("role", "guest"), v.In("admin", "user")).
+		Add(v.F("age", "1"), v.Gte(10)),
+	)
+
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal err: %v", err)
+	}
+	wantData := `[` +
+		`{"field":"name","kind":"INVALID","message":"has an invalid length","code":"len_string","params":{"max":5,"min":1}},` +
+		`{"field":"role","kind":"INVALID","message":"is not one of the given values","code":"in","params":{"values":["admin","user"]}},` +
+		`{"field":"age","kind":"UNSUPPORTED","message":"Gte expected int but got string"}` +
+		`]`
+	if string(data) != wantData {
+		t.Errorf("Got (%s) != Want (%s)", data, wantData)
+	}
+
+	var gotErrs v.Errors
+	if err := json.Unmarshal(data, &gotErrs); err != nil {
+		t.Fatalf("Unmarshal err: %v", err)
+	}
+	wantErrs := v.Errors{
+		v.NewCodedError("name", v.ErrInvalid, "has an invalid length", "len_string", map[string]any{"min": 1.0, "max": 5.0}),
+		v.NewCodedError("role", v.ErrInvalid, "is not one of the given values", "in", map[string]any{"values": []any{"admin", "user"}}),
+		v.NewError("age", v.ErrUnsupported, "Gte expected int but got string"),
+	}
+	if !reflect.DeepEqual(gotErrs, wantErrs) {
+		t.Errorf("Got (%+v) != Want (%+v)", gotErrs, wantErrs)
+	}
+	if gotErrs.Error() != errs.Error() {
+		t.Errorf("Got (%s) != Want (%s)", gotErrs.Error(), errs.Error())
+	}
+}
+
+func TestErrors_JSONNull(t *testing.T) {
+	var errs v.Errors
+
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal err: %v", err)
+	}
+	if string(data) != "null" {
+		t.Errorf("Got (%s) != Want (null)", data)
+	}
+
+	if err := json.Unmarshal(data, &errs); err != nil {
+		t.Fatalf("Unmarshal err: %v", err)
+	}
+	if errs != nil {
+		t.Errorf("Got (%+v) != Want (nil)", errs)
+	}
+}
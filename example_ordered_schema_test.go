@@ -0,0 +1,25 @@
+package validating_test
+
+import (
+	"fmt"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func Example_orderedSchema() {
+	p := Person{}
+	err := v.Validate(v.OrderedSchema{}.
+		Add(v.F("name", p.Name), v.LenString(1, 5)).
+		Add(v.F("age", p.Age), v.Gte(10)).
+		Add(v.F("address", p.Address), v.Nested(func(addr Address) v.Validator {
+			return v.OrderedSchema{}.
+				Add(v.F("country", addr.Country), v.Nonzero[string]()).
+				Add(v.F("province", addr.Province), v.Nonzero[string]()).
+				Add(v.F("city", addr.City), v.Nonzero[string]())
+		})),
+	)
+	fmt.Printf("err: %+v\n", err)
+
+	// Output:
+	// err: name: INVALID(has an invalid length), age: INVALID(is lower than the given value), address.country: INVALID(is zero valued), address.province: INVALID(is zero valued), address.city: INVALID(is zero valued)
+}
@@ -0,0 +1,108 @@
+package validating
+
+import (
+	"reflect"
+)
+
+// The group validators check the presence of several fields collectively,
+// where a field is present if its value is nonzero. Unlike the other
+// validators, they ignore the value of the field being validated, and
+// report a single error on it, with the names of the involved fields in
+// the param "fields". Usually, they are associated with a field that has an
+// empty name, which makes the error reported on the enclosing schema:
+//
+//	v.Schema{
+//		email: v.Match(`@`),
+//		phone: v.LenString(5, 20),
+//		v.F("", nil): v.ExactlyOneOf(email, phone),
+//	}
+
+// ExactlyOneOf is a group validator factory used to create a validator, which
+// will succeed when exactly one of the given fields is present.
+func ExactlyOneOf(fields ...*Field) *MessageValidator {
+	return group("exactly_one_of", "exactly one of {fields} is required", fields, func(n int) bool {
+		return n == 1
+	})
+}
+
+// AtLeastOneOf is a group validator factory used to create a validator, which
+// will succeed when at least one of the given fields is present.
+func AtLeastOneOf(fields ...*Field) *MessageValidator {
+	return group("at_least_one_of", "at least one of {fields} is required", fields, func(n int) bool {
+		return n >= 1
+	})
+}
+
+// MutuallyExclusive is a group validator factory used to create a validator,
+// which will succeed when at most one of the given fields is present.
+func MutuallyExclusive(fields ...*Field) *MessageValidator {
+	return group("mutually_exclusive", "at most one of {fields} is allowed", fields, func(n int) bool {
+		return n <= 1
+	})
+}
+
+// RequiredWith is a group validator factory used to create a validator, which
+// will succeed when either the field is absent, or all the given fields are
+// present. The name of the field is available as the param "field".
+func RequiredWith(field *Field, fields ...*Field) (mv *MessageValidator) {
+	mv = group("required_with", "{fields} are required when {field} is present", fields, func(n int) bool {
+		return n == len(fields)
+	})
+	mv.Params["field"] = field.Name
+
+	validator := mv.Validator
+	mv.Validator = Func(func(f *Field) Errors {
+		if !isPresent(field.Value) {
+			return nil
+		}
+		return validator.Validate(f)
+	})
+	return
+}
+
+// group creates a validator, which succeeds when valid reports true for the
+// number of present fields.
+func group(code, message string, fields []*Field, valid func(n int) bool) (mv *MessageValidator) {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+
+	mv = &MessageValidator{
+		Message: message,
+		Code:    code,
+		Params:  map[string]any{"fields": names},
+		Validator: Func(func(field *Field) Errors {
+			n := 0
+			for _, f := range fields {
+				if isPresent(f.Value) {
+					n++
+				}
+			}
+
+			if !valid(n) {
+				return mv.Invalid(field)
+			}
+			return nil
+		}),
+	}
+	return
+}
+
+// isPresent reports whether value is neither nil nor zero valued.
+func isPresent(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case interface{ IsZero() bool }:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return false
+		}
+		return !v.IsZero()
+	default:
+		return !reflect.ValueOf(value).IsZero()
+	}
+}
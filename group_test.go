@@ -0,0 +1,148 @@
+package validating_test
+
+import (
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestGroup(t *testing.T) {
+	type Contact struct {
+		Email string
+		Phone string
+	}
+	type Address struct {
+		Street string
+		City   string
+		Zip    *string
+	}
+	zip := "10001"
+
+	cases := []struct {
+		name   string
+		schema v.Schema
+		errs   v.Errors
+	}{
+		{
+			name: "exactly one of",
+			schema: func(c Contact) v.Schema {
+				email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+				return v.Schema{v.F("", nil): v.ExactlyOneOf(email, phone)}
+			}(Contact{Email: "a@b.c"}),
+			errs: nil,
+		},
+		{
+			name: "exactly one of with none",
+			schema: func(c Contact) v.Schema {
+				email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+				return v.Schema{v.F("", nil): v.ExactlyOneOf(email, phone)}
+			}(Contact{}),
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "exactly one of email, phone is required", "exactly_one_of", map[string]any{"fields": []string{"email", "phone"}}),
+			},
+		},
+		{
+			name: "exactly one of with both",
+			schema: func(c Contact) v.Schema {
+				email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+				return v.Schema{v.F("", nil): v.ExactlyOneOf(email, phone)}
+			}(Contact{Email: "a@b.c", Phone: "123"}),
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "exactly one of email, phone is required", "exactly_one_of", map[string]any{"fields": []string{"email", "phone"}}),
+			},
+		},
+		{
+			name: "at least one of",
+			schema: func(c Contact) v.Schema {
+				email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+				return v.Schema{v.F("", nil): v.AtLeastOneOf(email, phone)}
+			}(Contact{Email: "a@b.c", Phone: "123"}),
+			errs: nil,
+		},
+		{
+			name: "at least one of with none",
+			schema: func(c Contact) v.Schema {
+				email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+				return v.Schema{v.F("", nil): v.AtLeastOneOf(email, phone)}
+			}(Contact{}),
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "at least one of email, phone is required", "at_least_one_of", map[string]any{"fields": []string{"email", "phone"}}),
+			},
+		},
+		{
+			name: "mutually exclusive with none",
+			schema: func(c Contact) v.Schema {
+				email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+				return v.Schema{v.F("", nil): v.MutuallyExclusive(email, phone)}
+			}(Contact{}),
+			errs: nil,
+		},
+		{
+			name: "mutually exclusive with both",
+			schema: func(c Contact) v.Schema {
+				email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+				return v.Schema{v.F("", nil): v.MutuallyExclusive(email, phone)}
+			}(Contact{Email: "a@b.c", Phone: "123"}),
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "at most one of email, phone is allowed", "mutually_exclusive", map[string]any{"fields": []string{"email", "phone"}}),
+			},
+		},
+		{
+			name: "required with absent field",
+			schema: func(a Address) v.Schema {
+				street, city, zip := v.F("street", a.Street), v.F("city", a.City), v.F("zip", a.Zip)
+				return v.Schema{v.F("", nil): v.RequiredWith(street, city, zip)}
+			}(Address{}),
+			errs: nil,
+		},
+		{
+			name: "required with all fields",
+			schema: func(a Address) v.Schema {
+				street, city, zip := v.F("street", a.Street), v.F("city", a.City), v.F("zip", a.Zip)
+				return v.Schema{v.F("", nil): v.RequiredWith(street, city, zip)}
+			}(Address{Street: "x", City: "y", Zip: &zip}),
+			errs: nil,
+		},
+		{
+			name: "required with missing fields",
+			schema: func(a Address) v.Schema {
+				street, city, zip := v.F("street", a.Street), v.F("city", a.City), v.F("zip", a.Zip)
+				return v.Schema{v.F("", nil): v.RequiredWith(street, city, zip)}
+			}(Address{Street: "x", City: "y"}),
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "city, zip are required when street is present", "required_with", map[string]any{"field": "street", "fields": []string{"city", "zip"}}),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(c.schema)
+			if !reflect.DeepEqual(errs, c.errs) {
+				t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+			}
+		})
+	}
+}
+
+func TestGroup_Nested(t *testing.T) {
+	type Contact struct {
+		Email string
+		Phone string
+	}
+	schema := func(c Contact) v.Validator {
+		email, phone := v.F("email", c.Email), v.F("phone", c.Phone)
+		return v.Schema{
+			email:        v.ZeroOr[string](v.Match(`@`)),
+			v.F("", nil): v.ExactlyOneOf(email, phone),
+		}
+	}
+
+	errs := v.Validate(v.Schema{v.F("contact", Contact{}): v.Nested(schema)})
+	want := v.Errors{
+		v.NewCodedError("contact", v.ErrInvalid, "exactly one of email, phone is required", "exactly_one_of", map[string]any{"fields": []string{"email", "phone"}}),
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Got (%+v) != Want (%+v)", errs, want)
+	}
+}
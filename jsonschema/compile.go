@@ -0,0 +1,555 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+// annotations are the keywords that do not affect the validation.
+var annotations = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+	"format":      true, // Annotation only, as per draft 2020-12.
+	"deprecated":  true,
+	"readOnly":    true,
+	"writeOnly":   true,
+}
+
+// Compile compiles the given JSON Schema document into a validator.
+//
+// See CompileSchema for details.
+func Compile(doc []byte) (v.Validator, error) {
+	var s any
+	if err := json.Unmarshal(doc, &s); err != nil {
+		return nil, err
+	}
+	return compile(s, "#")
+}
+
+// CompileSchema compiles the given JSON Schema into a validator, which is
+// composed of the built-in validators, and validates dynamic data decoded
+// from JSON (i.e. map[string]any, []any, string, float64, json.Number,
+// bool and nil). Numbers of Go numeric types are also supported.
+//
+// Only a subset of draft 2020-12 is supported: type, enum, const, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength,
+// pattern, items, minItems, maxItems, properties, required,
+// additionalProperties, allOf, anyOf and not. Annotations (e.g. title and
+// format) are ignored, while any other keyword results in an error.
+func CompileSchema(s Schema) (v.Validator, error) {
+	return compile(s, "#")
+}
+
+func compile(s any, path string) (v.Validator, error) {
+	switch s := s.(type) {
+	case bool:
+		if s {
+			return accept(), nil
+		}
+		return v.Not(accept()), nil
+	case map[string]any:
+		return compileObject(s, path)
+	default:
+		return nil, fmt.Errorf("jsonschema: %s: schema must be an object or a boolean, got %T", path, s)
+	}
+}
+
+func compileObject(s Schema, path string) (v.Validator, error) {
+	c := &compiler{schema: s, path: path}
+
+	var validators []v.Validator
+	// Keywords are compiled in a fixed order, so that the type is checked
+	// before the others.
+	for _, kw := range keywordOrder {
+		if _, ok := s[kw.name]; !ok {
+			continue
+		}
+		validator, err := kw.compile(c)
+		if err != nil {
+			return nil, err
+		}
+		if validator != nil {
+			validators = append(validators, validator)
+		}
+	}
+
+	for _, name := range sortedKeys(s) {
+		if !annotations[name] && !isKeyword(name) {
+			return nil, fmt.Errorf("jsonschema: %s: unsupported keyword %q", path, name)
+		}
+	}
+
+	switch len(validators) {
+	case 0:
+		return accept(), nil
+	case 1:
+		return validators[0], nil
+	default:
+		return v.All(validators...), nil
+	}
+}
+
+type keyword struct {
+	name    string
+	compile func(c *compiler) (v.Validator, error)
+}
+
+var keywordOrder []keyword
+
+func init() {
+	keywordOrder = []keyword{
+		{"type", (*compiler).compileType},
+		{"enum", (*compiler).compileEnum},
+		{"const", (*compiler).compileConst},
+		{"minimum", compileNumber("minimum", v.Gte[float64])},
+		{"maximum", compileNumber("maximum", v.Lte[float64])},
+		{"exclusiveMinimum", compileNumber("exclusiveMinimum", v.Gt[float64])},
+		{"exclusiveMaximum", compileNumber("exclusiveMaximum", v.Lt[float64])},
+		{"minLength", (*compiler).compileLength},
+		{"maxLength", (*compiler).compileLength},
+		{"pattern", (*compiler).compilePattern},
+		{"items", (*compiler).compileItems},
+		{"minItems", (*compiler).compileItemCount},
+		{"maxItems", (*compiler).compileItemCount},
+		{"properties", (*compiler).compileProperties},
+		{"required", (*compiler).compileProperties},
+		{"additionalProperties", (*compiler).compileProperties},
+		{"allOf", (*compiler).compileAllOf},
+		{"anyOf", (*compiler).compileAnyOf},
+		{"not", (*compiler).compileNot},
+	}
+}
+
+func isKeyword(name string) bool {
+	for _, kw := range keywordOrder {
+		if kw.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// compiler compiles the keywords of a schema object.
+type compiler struct {
+	schema Schema
+	path   string
+
+	lengthDone     bool // Whether minLength and maxLength have been compiled.
+	itemCountDone  bool // Whether minItems and maxItems have been compiled.
+	propertiesDone bool // Whether the object keywords have been compiled.
+}
+
+func (c *compiler) errorf(keyword, format string, args ...any) error {
+	return fmt.Errorf("jsonschema: %s/%s: "+format, append([]any{c.path, keyword}, args...)...)
+}
+
+func (c *compiler) compileType() (v.Validator, error) {
+	var types []string
+	switch t := c.schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, e := range t {
+			s, ok := e.(string)
+			if !ok {
+				return nil, c.errorf("type", "must be a string or an array of strings")
+			}
+			types = append(types, s)
+		}
+	default:
+		return nil, c.errorf("type", "must be a string or an array of strings")
+	}
+
+	for _, t := range types {
+		switch t {
+		case "null", "boolean", "object", "array", "number", "integer", "string":
+		default:
+			return nil, c.errorf("type", "unknown type %q", t)
+		}
+	}
+
+	expected := types[0]
+	if len(types) > 1 {
+		expected = strings.Join(types, " or ")
+	}
+
+	var mv *v.MessageValidator
+	mv = &v.MessageValidator{
+		Message: "must be of type {expected}",
+		Code:    "type",
+		Params:  map[string]any{"expected": expected},
+		Validator: v.Func(func(field *v.Field) v.Errors {
+			for _, t := range types {
+				if hasType(field.Value, t) {
+					return nil
+				}
+			}
+			return mv.Invalid(field)
+		}),
+	}
+	return mv, nil
+}
+
+func (c *compiler) compileEnum() (v.Validator, error) {
+	values, ok := c.schema["enum"].([]any)
+	if !ok {
+		return nil, c.errorf("enum", "must be an array")
+	}
+	return oneOf("in", map[string]any{"values": values}, "is not one of the given values", values), nil
+}
+
+func (c *compiler) compileConst() (v.Validator, error) {
+	value := c.schema["const"]
+	return oneOf("eq", map[string]any{"eq": value}, "does not equal the given value", []any{value}), nil
+}
+
+func compileNumber(keyword string, factory func(float64) *v.MessageValidator) func(*compiler) (v.Validator, error) {
+	return func(c *compiler) (v.Validator, error) {
+		n, ok := toFloat64(c.schema[keyword])
+		if !ok {
+			return nil, c.errorf(keyword, "must be a number")
+		}
+		return numeric(factory(n)), nil
+	}
+}
+
+func (c *compiler) compileLength() (v.Validator, error) {
+	if c.lengthDone {
+		return nil, nil
+	}
+	c.lengthDone = true
+
+	min, max, err := c.bounds("minLength", "maxLength")
+	if err != nil {
+		return nil, err
+	}
+	// JSON Schema counts the length of a string in code points.
+	return onlyIf("string", v.RuneCount(min, max)), nil
+}
+
+func (c *compiler) compilePattern() (v.Validator, error) {
+	pattern, ok := c.schema["pattern"].(string)
+	if !ok {
+		return nil, c.errorf("pattern", "must be a string")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, c.errorf("pattern", "%v", err)
+	}
+	return onlyIf("string", v.Match(re)), nil
+}
+
+func (c *compiler) compileItems() (v.Validator, error) {
+	items, err := compile(c.schema["items"], c.path+"/items")
+	if err != nil {
+		return nil, err
+	}
+	return onlyIf("array", v.EachSlice[[]any](items)), nil
+}
+
+func (c *compiler) compileItemCount() (v.Validator, error) {
+	if c.itemCountDone {
+		return nil, nil
+	}
+	c.itemCountDone = true
+
+	min, max, err := c.bounds("minItems", "maxItems")
+	if err != nil {
+		return nil, err
+	}
+	return onlyIf("array", v.LenSlice[[]any](min, max)), nil
+}
+
+func (c *compiler) compileProperties() (v.Validator, error) {
+	if c.propertiesDone {
+		return nil, nil
+	}
+	c.propertiesDone = true
+
+	required := map[string]bool{}
+	if r, ok := c.schema["required"]; ok {
+		keys, ok := r.([]any)
+		if !ok {
+			return nil, c.errorf("required", "must be an array of strings")
+		}
+		for _, k := range keys {
+			key, ok := k.(string)
+			if !ok {
+				return nil, c.errorf("required", "must be an array of strings")
+			}
+			required[key] = true
+		}
+	}
+
+	var props []*v.Property
+	declared := map[string]bool{}
+	if p, ok := c.schema["properties"]; ok {
+		properties, ok := p.(map[string]any)
+		if !ok {
+			return nil, c.errorf("properties", "must be an object")
+		}
+		for _, key := range sortedKeys(properties) {
+			validator, err := compile(properties[key], c.path+"/properties/"+key)
+			if err != nil {
+				return nil, err
+			}
+			prop := v.Prop(key, validator)
+			if required[key] {
+				prop.Required()
+			}
+			props = append(props, prop)
+			declared[key] = true
+		}
+	}
+	for _, key := range sortedKeys(required) {
+		if !declared[key] {
+			props = append(props, v.Prop(key, nil).Required())
+		}
+	}
+
+	object := v.Object(props...)
+	switch additional := c.schema["additionalProperties"].(type) {
+	case nil:
+	case bool:
+		if !additional {
+			object.Strict()
+		}
+	default:
+		validator, err := compile(additional, c.path+"/additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		object.Additional(validator)
+	}
+
+	return onlyIf("object", object), nil
+}
+
+func (c *compiler) compileAllOf() (v.Validator, error) {
+	validators, err := c.compileSubschemas("allOf")
+	if err != nil {
+		return nil, err
+	}
+	return v.All(validators...), nil
+}
+
+func (c *compiler) compileAnyOf() (v.Validator, error) {
+	validators, err := c.compileSubschemas("anyOf")
+	if err != nil {
+		return nil, err
+	}
+	return v.Any(validators...), nil
+}
+
+func (c *compiler) compileNot() (v.Validator, error) {
+	validator, err := compile(c.schema["not"], c.path+"/not")
+	if err != nil {
+		return nil, err
+	}
+	return v.Not(validator), nil
+}
+
+func (c *compiler) compileSubschemas(keyword string) ([]v.Validator, error) {
+	schemas, ok := c.schema[keyword].([]any)
+	if !ok || len(schemas) == 0 {
+		return nil, c.errorf(keyword, "must be a non-empty array")
+	}
+
+	var validators []v.Validator
+	for i, s := range schemas {
+		validator, err := compile(s, fmt.Sprintf("%s/%s/%d", c.path, keyword, i))
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, validator)
+	}
+	return validators, nil
+}
+
+// bounds returns the values of the given keywords, which must be
+// non-negative integers.
+func (c *compiler) bounds(minKeyword, maxKeyword string) (min, max int, err error) {
+	min, max = 0, math.MaxInt
+	for _, b := range []struct {
+		keyword string
+		dst     *int
+	}{{minKeyword, &min}, {maxKeyword, &max}} {
+		value, ok := c.schema[b.keyword]
+		if !ok {
+			continue
+		}
+		n, ok := toFloat64(value)
+		if !ok || n < 0 || n != math.Trunc(n) {
+			return 0, 0, c.errorf(b.keyword, "must be a non-negative integer")
+		}
+		*b.dst = int(n)
+	}
+	return min, max, nil
+}
+
+// accept creates a validator, which always succeeds.
+func accept() v.Validator {
+	return v.Func(func(field *v.Field) v.Errors { return nil })
+}
+
+// onlyIf creates a validator, which applies validator only if the field's
+// value is of the given JSON type.
+func onlyIf(jsonType string, validator v.Validator) v.Validator {
+	return v.WithDescription(v.Func(func(field *v.Field) v.Errors {
+		if !hasType(field.Value, jsonType) {
+			return nil
+		}
+		return validator.Validate(field)
+	}), v.Describe(validator))
+}
+
+// numeric creates a validator, which applies validator to the field's value
+// converted to float64, only if the value is a number.
+func numeric(validator v.Validator) v.Validator {
+	return v.WithDescription(v.Func(func(field *v.Field) v.Errors {
+		n, ok := toFloat64(field.Value)
+		if !ok {
+			return nil
+		}
+		return v.Value(n, validator).Validate(field)
+	}), v.Describe(validator))
+}
+
+// oneOf creates a validator, which succeeds if the field's value equals one
+// of the given values (as JSON values).
+func oneOf(code string, params map[string]any, msg string, values []any) v.Validator {
+	var mv *v.MessageValidator
+	mv = &v.MessageValidator{
+		Message: msg,
+		Code:    code,
+		Params:  params,
+		Validator: v.Func(func(field *v.Field) v.Errors {
+			for _, value := range values {
+				if jsonEqual(field.Value, value) {
+					return nil
+				}
+			}
+			return mv.Invalid(field)
+		}),
+	}
+	return mv
+}
+
+func hasType(value any, jsonType string) bool {
+	switch jsonType {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := toFloat64(value)
+		return ok
+	case "integer":
+		n, ok := toFloat64(value)
+		return ok && n == math.Trunc(n) && !math.IsInf(n, 0)
+	default:
+		return false
+	}
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// jsonEqual reports whether a and b are equal as JSON values.
+func jsonEqual(a, b any) bool {
+	if x, ok := toFloat64(a); ok {
+		y, ok := toFloat64(b)
+		return ok && x == y
+	}
+
+	switch a := a.(type) {
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, av := range a {
+			bv, ok := b[k]
+			if !ok || !jsonEqual(av, bv) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,169 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/jsonschema"
+)
+
+const personDoc = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Person",
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "minLength": 1, "maxLength": 5, "pattern": "^[a-z]+$"},
+    "age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+    "role": {"enum": ["admin", "user"]},
+    "tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+    "email": {"anyOf": [{"const": ""}, {"type": "string", "pattern": "@"}]},
+    "address": {
+      "type": ["object", "null"],
+      "properties": {"city": {"type": "string"}},
+      "required": ["city"],
+      "additionalProperties": false
+    }
+  },
+  "required": ["name", "age"],
+  "additionalProperties": {"type": "string"}
+}`
+
+type field struct {
+	Message string
+	Code    string
+}
+
+func toFields(errs v.Errors) map[string]field {
+	if len(errs) == 0 {
+		return nil
+	}
+	m := make(map[string]field, len(errs))
+	for _, err := range errs {
+		var code string
+		if ce, ok := err.(v.CodedError); ok {
+			code = ce.Code()
+		}
+		m[err.Field()] = field{Message: err.Message(), Code: code}
+	}
+	return m
+}
+
+func TestCompile(t *testing.T) {
+	validator, err := jsonschema.Compile([]byte(personDoc))
+	if err != nil {
+		t.Fatalf("Compile err: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		data string
+		want map[string]field
+	}{
+		{
+			name: "valid",
+			data: `{"name": "foo", "age": 20, "role": "user", "tags": ["a"], "email": "", "address": null, "extra": "x"}`,
+		},
+		{
+			name: "not an object",
+			data: `[1, 2]`,
+			want: map[string]field{
+				"": {Message: "must be of type object", Code: "type"},
+			},
+		},
+		{
+			name: "missing required",
+			data: `{"name": "foo"}`,
+			want: map[string]field{
+				"age": {Message: "is required", Code: "required"},
+			},
+		},
+		{
+			name: "invalid values",
+			data: `{"name": "Foobar", "age": 1.5, "role": "root", "tags": ["a", 1], "email": "x", "address": {"zip": "1"}, "extra": 1}`,
+			want: map[string]field{
+				"name":         {Message: "the number of runes is not between the given range", Code: "rune_count"},
+				"age":          {Message: "must be of type integer", Code: "type"},
+				"role":         {Message: "is not one of the given values", Code: "in"},
+				"tags[1]":      {Message: "must be of type string", Code: "type"},
+				"email":        {Message: "does not match the given regular expression", Code: "match"},
+				"address.city": {Message: "is required", Code: "required"},
+				"address.zip":  {Message: "is not allowed", Code: "unknown_field"},
+				"extra":        {Message: "must be of type string", Code: "type"},
+			},
+		},
+		{
+			name: "out of range",
+			data: `{"name": "foo", "age": 150, "tags": ["a", "b", "c"], "address": {"city": 1}}`,
+			want: map[string]field{
+				"age":          {Message: "is greater than or equal to the given value", Code: "lt"},
+				"tags":         {Message: "has an invalid length", Code: "len_slice"},
+				"address.city": {Message: "must be of type string", Code: "type"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(c.data), &data); err != nil {
+				t.Fatalf("Unmarshal err: %v", err)
+			}
+			errs := v.Validate(v.Value(data, validator))
+			if got := toFields(errs); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", got, c.want)
+			}
+		})
+	}
+}
+
+func TestCompileSchema(t *testing.T) {
+	validator, err := jsonschema.CompileSchema(jsonschema.Schema{
+		"allOf": []any{
+			map[string]any{"type": "number", "minimum": 1},
+			map[string]any{"not": map[string]any{"const": 3}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CompileSchema err: %v", err)
+	}
+
+	cases := []struct {
+		value any
+		want  map[string]field
+	}{
+		{value: 2},
+		{value: json.Number("2.5")},
+		{value: "2", want: map[string]field{"": {Message: "must be of type number", Code: "type"}}},
+		{value: 0.5, want: map[string]field{"": {Message: "is lower than the given value", Code: "gte"}}},
+		{value: 3.0, want: map[string]field{"": {Message: "is invalid", Code: "not"}}},
+	}
+	for _, c := range cases {
+		errs := v.Validate(v.Value(c.value, validator))
+		if got := toFields(errs); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("value %v: Got (%+v) != Want (%+v)", c.value, got, c.want)
+		}
+	}
+}
+
+func TestCompile_Error(t *testing.T) {
+	cases := []struct {
+		doc  string
+		want string
+	}{
+		{doc: `1`, want: "jsonschema: #: schema must be an object or a boolean, got float64"},
+		{doc: `{"$ref": "#/$defs/a"}`, want: `jsonschema: #: unsupported keyword "$ref"`},
+		{doc: `{"type": "str"}`, want: `jsonschema: #/type: unknown type "str"`},
+		{doc: `{"minLength": -1}`, want: "jsonschema: #/minLength: must be a non-negative integer"},
+		{doc: `{"pattern": "("}`, want: "jsonschema: #/pattern: error parsing regexp: missing closing ): `(`"},
+		{doc: `{"items": {"minimum": "1"}}`, want: "jsonschema: #/items/minimum: must be a number"},
+		{doc: `{"anyOf": []}`, want: "jsonschema: #/anyOf: must be a non-empty array"},
+	}
+	for _, c := range cases {
+		_, err := jsonschema.Compile([]byte(c.doc))
+		if err == nil || err.Error() != c.want {
+			t.Errorf("doc %s: Got (%v) != Want (%s)", c.doc, err, c.want)
+		}
+	}
+}
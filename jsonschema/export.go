@@ -0,0 +1,454 @@
+// Package jsonschema converts between validators and JSON Schema
+// (draft 2020-12) documents.
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+// Draft is the URI of the JSON Schema dialect used by this package.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document (or a fragment of it).
+type Schema = map[string]any
+
+// codeFragment is the description code of validators declaring their own
+// JSON Schema fragments.
+const codeFragment = "json_schema"
+
+// Fragment returns a validator, which delegates the actual validation to
+// validator, but declares its rules as the given JSON Schema fragment.
+//
+// It's the escape hatch for opaque validators, such as v.Func and v.Is:
+//
+//	jsonschema.Fragment(v.Is(isIP), jsonschema.Schema{"format": "ipv4"})
+func Fragment(validator v.Validator, fragment Schema) v.Validator {
+	return v.WithDescription(validator, v.Description{
+		Code:   codeFragment,
+		Params: map[string]any{"schema": fragment},
+	})
+}
+
+// Options holds the options for exporting.
+type Options struct {
+	// Refs maps Go types to the URIs of their schemas. Values of these
+	// types are exported as references (i.e. {"$ref": uri}) instead of
+	// inline schemas.
+	Refs map[reflect.Type]string
+}
+
+// Export exports the given validator (typically a v.Schema returned by the
+// Schema method of a struct) as a JSON Schema document.
+//
+// Validators are exported per their descriptions (see v.Describe), and
+// the types of the fields are derived from the Go types of their values.
+// Opaque validators, which do not implement v.Describer, are exported as
+// empty schemas.
+func Export(validator v.Validator) Schema {
+	s := ExportWithOptions(validator, Options{})
+	s["$schema"] = Draft
+	return s
+}
+
+// ExportWithOptions is like Export, but allows customizations. The
+// "$schema" keyword is not included in the returned schema.
+func ExportWithOptions(validator v.Validator, opts Options) Schema {
+	e := &exporter{opts: opts}
+	s := Schema{}
+	e.merge(s, v.Describe(validator), nil)
+	return s
+}
+
+type exporter struct {
+	opts Options
+}
+
+// typeSchema returns the schema derived from the given Go type.
+func (e *exporter) typeSchema(t reflect.Type) Schema {
+	if t == nil {
+		return Schema{}
+	}
+	t = valueType(t)
+
+	if ref, ok := e.opts.Refs[t]; ok {
+		return Schema{"$ref": ref}
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return Schema{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array"}
+	case reflect.Map, reflect.Struct:
+		return Schema{"type": "object"}
+	default:
+		return Schema{}
+	}
+}
+
+// merge merges the schema of the given description, which applies to values
+// of type t (nil if unknown), into s.
+func (e *exporter) merge(s Schema, desc v.Description, t reflect.Type) {
+	if t != nil {
+		t = valueType(t)
+	}
+
+	switch desc.Code {
+	case "schema":
+		e.mergeSchema(s, desc)
+	case "object":
+		e.mergeObject(s, desc)
+	case "nested":
+		if _, ok := e.opts.Refs[t]; ok {
+			// Referenced by $ref.
+			return
+		}
+		if nested, ok := e.applyNested(desc, t); ok {
+			e.merge(s, nested, t)
+		}
+	case "each_slice":
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		s["items"] = e.sub(desc.Validators, elem)
+	case "each_map":
+		var elem reflect.Type
+		if t != nil && t.Kind() == reflect.Map {
+			elem = t.Elem()
+		}
+		s["additionalProperties"] = e.sub(desc.Validators, elem)
+	case "all":
+		for _, d := range desc.Validators {
+			sub := Schema{}
+			e.merge(sub, d, t)
+			mergeOrAllOf(s, sub)
+		}
+	case "any":
+		var anyOf []any
+		for _, d := range desc.Validators {
+			sub := Schema{}
+			e.merge(sub, d, t)
+			anyOf = append(anyOf, sub)
+		}
+		if len(anyOf) > 0 {
+			s["anyOf"] = anyOf
+		}
+	case "when":
+		// The condition can not be expressed in JSON Schema, hence either
+		// branch is allowed, and the condition is documented instead. Without
+		// an else-branch, any value (i.e. true) is allowed otherwise.
+		var anyOf []any
+		for _, d := range desc.Validators {
+			sub := Schema{}
+			e.merge(sub, d, t)
+			anyOf = append(anyOf, sub)
+		}
+		if len(anyOf) == 1 {
+			anyOf = append(anyOf, true)
+		}
+		s["anyOf"] = anyOf
+		if condition, ok := desc.Params["condition"].(string); ok {
+			s["description"] = "Validated by the first schema of anyOf if " + condition + ", or by the second one otherwise."
+		}
+	case "required", "optional":
+		// Presence is expressed by the "required" keyword of the parent.
+		mergeOrAllOf(s, e.rules(desc.Validators, t))
+	case "not":
+		s["not"] = e.rules(desc.Validators, t)
+	case codeFragment:
+		if fragment, ok := desc.Params["schema"].(Schema); ok {
+			mergeOrAllOf(s, fragment)
+		}
+	default:
+		for k, v := range ruleSchema(desc, t) {
+			s[k] = v
+		}
+	}
+}
+
+// sub returns the schema of the given descriptions, which apply to values
+// of type t, along with the schema derived from t.
+func (e *exporter) sub(descs []v.Description, t reflect.Type) Schema {
+	s := e.typeSchema(t)
+	if _, ok := s["$ref"]; ok {
+		return s
+	}
+	mergeOrAllOf(s, e.rules(descs, t))
+	return s
+}
+
+// rules returns the schema of the given descriptions, which apply to values
+// of type t, without the schema derived from t.
+func (e *exporter) rules(descs []v.Description, t reflect.Type) Schema {
+	s := Schema{}
+	for _, d := range descs {
+		sub := Schema{}
+		e.merge(sub, d, t)
+		mergeOrAllOf(s, sub)
+	}
+	return s
+}
+
+func (e *exporter) applyNested(desc v.Description, t reflect.Type) (v.Description, bool) {
+	if desc.Apply != nil && t != nil {
+		// Prefer a non-nil value, since Nested functions usually
+		// dereference pointers.
+		ptr := reflect.New(t)
+		if d, ok := desc.Apply(ptr.Interface()); ok {
+			return d, true
+		}
+		if d, ok := desc.Apply(ptr.Elem().Interface()); ok {
+			return d, true
+		}
+	}
+	if len(desc.Validators) > 0 {
+		return desc.Validators[0], true
+	}
+	return v.Description{}, false
+}
+
+func (e *exporter) mergeSchema(s Schema, desc v.Description) {
+	props, _ := s["properties"].(Schema)
+	if props == nil {
+		props = Schema{}
+	}
+	required, _ := s["required"].([]string)
+
+	for _, f := range desc.Fields {
+		t := reflect.TypeOf(f.Value)
+		if f.Name == "" {
+			// The field represents the schema itself (e.g. created by v.Value).
+			e.merge(s, f.Description, t)
+			continue
+		}
+
+		props[f.Name] = e.sub([]v.Description{f.Description}, t)
+		if isRequired(f.Description) && !contains(required, f.Name) {
+			required = append(required, f.Name)
+		}
+	}
+
+	if len(props) > 0 {
+		s["type"] = "object"
+		s["properties"] = props
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+}
+
+func (e *exporter) mergeObject(s Schema, desc v.Description) {
+	s["type"] = "object"
+
+	props := Schema{}
+	for _, f := range desc.Fields {
+		props[f.Name] = e.sub([]v.Description{f.Description}, nil)
+	}
+	if len(props) > 0 {
+		s["properties"] = props
+	}
+
+	if required, ok := desc.Params["required"].([]string); ok {
+		s["required"] = required
+	}
+	if strict, _ := desc.Params["strict"].(bool); strict {
+		s["additionalProperties"] = false
+	} else if len(desc.Validators) > 0 {
+		s["additionalProperties"] = e.sub(desc.Validators, nil)
+	}
+}
+
+// isRequired reports whether the field described by desc must be present,
+// which is the case if the field must be nonzero.
+func isRequired(desc v.Description) bool {
+	switch desc.Code {
+	case "nonzero", "required":
+		return true
+	case "all":
+		for _, d := range desc.Validators {
+			if isRequired(d) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// valueType returns the type of the values held by t, which is t itself
+// unless it's a pointer, a v.Opt or one of the sql.Null* types.
+func valueType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.PkgPath() == "" {
+		return t
+	}
+
+	// v.Opt[T] and sql.Null[T] are generic, hence their names are
+	// suffixed with the type arguments.
+	switch name := t.PkgPath() + "." + t.Name(); {
+	case strings.HasPrefix(name, optName+"["):
+		return valueType(t.Field(0).Type) // Value
+	case strings.HasPrefix(name, "database/sql.Null"):
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.Name != "Valid" {
+				return valueType(f.Type)
+			}
+		}
+	}
+	return t
+}
+
+// optName is the qualified name of v.Opt, without the type arguments.
+var optName = func() string {
+	t := reflect.TypeOf(v.Opt[int]{})
+	name := t.PkgPath() + "." + t.Name()
+	return name[:strings.IndexByte(name, '[')]
+}()
+
+// ruleSchema returns the schema of the given leaf rule, which applies to
+// values of type t.
+func ruleSchema(desc v.Description, t reflect.Type) Schema {
+	p := desc.Params
+	switch desc.Code {
+	case "nonzero":
+		if t != nil && t.Kind() == reflect.String {
+			return Schema{"minLength": 1}
+		}
+	case "zero":
+		if t != nil {
+			return Schema{"const": reflect.Zero(t).Interface()}
+		}
+	case "rune_count":
+		return Schema{"minLength": p["min"], "maxLength": p["max"]}
+	case "len_string":
+		// The length is counted in bytes, while minLength and maxLength
+		// count characters, hence the bounds are kept as extensions.
+		return Schema{"x-minBytes": p["min"], "x-maxBytes": p["max"]}
+	case "len_slice":
+		return Schema{"minItems": p["min"], "maxItems": p["max"]}
+	case "eq":
+		return Schema{"const": p["eq"]}
+	case "ne":
+		return Schema{"not": Schema{"const": p["ne"]}}
+	case "gt":
+		if isNumber(p["gt"]) {
+			return Schema{"exclusiveMinimum": p["gt"]}
+		}
+	case "gte":
+		if isNumber(p["gte"]) {
+			return Schema{"minimum": p["gte"]}
+		}
+	case "lt":
+		if isNumber(p["lt"]) {
+			return Schema{"exclusiveMaximum": p["lt"]}
+		}
+	case "lte":
+		if isNumber(p["lte"]) {
+			return Schema{"maximum": p["lte"]}
+		}
+	case "range":
+		if isNumber(p["min"]) {
+			return Schema{"minimum": p["min"], "maximum": p["max"]}
+		}
+	case "in":
+		return Schema{"enum": p["values"]}
+	case "nin":
+		return Schema{"not": Schema{"enum": p["values"]}}
+	case "match":
+		return Schema{"pattern": p["pattern"]}
+	case "exactly_one_of", "at_least_one_of", "mutually_exclusive":
+		// Presence is approximated by the properties being required.
+		fields, _ := p["fields"].([]string)
+		return groupSchema(desc.Code, fields)
+	case "required_with":
+		if field, ok := p["field"].(string); ok {
+			fields, _ := p["fields"].([]string)
+			return Schema{"dependentRequired": Schema{field: fields}}
+		}
+	}
+	return nil
+}
+
+// groupSchema returns the schema of the group rule with the given code.
+func groupSchema(code string, fields []string) Schema {
+	var each []any
+	for _, f := range fields {
+		each = append(each, Schema{"required": []string{f}})
+	}
+
+	switch code {
+	case "exactly_one_of":
+		return Schema{"oneOf": each}
+	case "at_least_one_of":
+		return Schema{"anyOf": each}
+	default: // mutually_exclusive
+		var pairs []any
+		for i := range fields {
+			for j := i + 1; j < len(fields); j++ {
+				pairs = append(pairs, Schema{"required": []string{fields[i], fields[j]}})
+			}
+		}
+		if len(pairs) == 0 {
+			return nil
+		}
+		return Schema{"not": Schema{"anyOf": pairs}}
+	}
+}
+
+func isNumber(value any) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// mergeOrAllOf merges sub into s if they have no conflicting keywords,
+// or appends sub to the "allOf" keyword of s otherwise.
+func mergeOrAllOf(s, sub Schema) {
+	if len(sub) == 0 {
+		return
+	}
+
+	for k, v := range sub {
+		if old, ok := s[k]; ok && !reflect.DeepEqual(old, v) {
+			allOf, _ := s["allOf"].([]any)
+			s["allOf"] = append(allOf, sub)
+			return
+		}
+	}
+	for k, v := range sub {
+		s[k] = v
+	}
+}
+
+func contains(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package jsonschema_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/jsonschema"
+)
+
+type Address struct {
+	Country string
+	City    string
+}
+
+func (a Address) Schema() v.Schema {
+	return v.Schema{
+		v.F("country", a.Country): v.Nonzero[string](),
+		v.F("city", a.City):       v.In("A", "B"),
+	}
+}
+
+type Person struct {
+	Name    string
+	Age     int
+	Email   string
+	IP      string
+	Tags    []string
+	Scores  map[string]float64
+	Address *Address
+	Backup  Address
+}
+
+func (p Person) Schema() v.Schema {
+	return v.Schema{
+		v.F("name", p.Name): v.All(
+			v.Nonzero[string](),
+			v.LenString(1, 10),
+			v.Match(`^\w+$`),
+		),
+		v.F("age", p.Age):       v.Range(0, 150),
+		v.F("email", p.Email):   v.Any(v.Zero[string](), v.Match(`@`)),
+		v.F("ip", p.IP):         jsonschema.Fragment(v.Is(func(s string) bool { return net.ParseIP(s) != nil }), jsonschema.Schema{"format": "ipv4"}),
+		v.F("tags", p.Tags):     v.All(v.LenSlice[[]string](0, 3), v.EachSlice[[]string](v.Not(v.In("x")))),
+		v.F("scores", p.Scores): v.EachMap[map[string]float64](v.Gte(0.0)),
+		v.F("address", p.Address): v.Nested(func(a *Address) v.Validator {
+			return a.Schema()
+		}),
+		v.F("backup", p.Backup): p.Backup.Schema(),
+	}
+}
+
+func TestExport(t *testing.T) {
+	got, err := json.Marshal(jsonschema.Export(Person{}.Schema()))
+	if err != nil {
+		t.Fatalf("Marshal err: %v", err)
+	}
+
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+		`"properties":{` +
+		`"address":{"properties":{"city":{"enum":["A","B"],"type":"string"},"country":{"minLength":1,"type":"string"}},"required":["country"],"type":"object"},` +
+		`"age":{"maximum":150,"minimum":0,"type":"integer"},` +
+		`"backup":{"properties":{"city":{"enum":["A","B"],"type":"string"},"country":{"minLength":1,"type":"string"}},"required":["country"],"type":"object"},` +
+		`"email":{"anyOf":[{"const":""},{"pattern":"@"}],"type":"string"},` +
+		`"ip":{"format":"ipv4","type":"string"},` +
+		`"name":{"minLength":1,"pattern":"^\\w+$","type":"string","x-maxBytes":10,"x-minBytes":1},` +
+		`"scores":{"additionalProperties":{"minimum":0,"type":"number"},"type":"object"},` +
+		`"tags":{"items":{"not":{"enum":["x"]},"type":"string"},"maxItems":3,"minItems":0,"type":"array"}` +
+		`},` +
+		`"required":["name"],` +
+		`"type":"object"}`
+	if string(got) != want {
+		t.Errorf("Got (%s) != Want (%s)", got, want)
+	}
+}
+
+func TestExport_Object(t *testing.T) {
+	validator := v.Object(
+		v.Prop("id", v.Gt(0.0)).Required(),
+		v.Prop("items", v.EachSlice[[]any](v.Object(
+			v.Prop("name", v.RuneCount(1, 5)),
+		).Additional(v.RuneCount(1, 5)))),
+	).Strict()
+
+	got, err := json.Marshal(jsonschema.ExportWithOptions(validator, jsonschema.Options{}))
+	if err != nil {
+		t.Fatalf("Marshal err: %v", err)
+	}
+
+	want := `{"additionalProperties":false,` +
+		`"properties":{` +
+		`"id":{"exclusiveMinimum":0},` +
+		`"items":{"items":{"additionalProperties":{"maxLength":5,"minLength":1},"properties":{"name":{"maxLength":5,"minLength":1}},"type":"object"}}` +
+		`},` +
+		`"required":["id"],` +
+		`"type":"object"}`
+	if string(got) != want {
+		t.Errorf("Got (%s) != Want (%s)", got, want)
+	}
+}
+
+func TestExport_When(t *testing.T) {
+	type Customer struct {
+		Type  string
+		VatID string
+	}
+	c := Customer{}
+	validator := v.Schema{
+		v.F("type", c.Type): v.In("person", "company"),
+		v.F("vat_id", c.VatID): v.When(c.Type == "company", v.RuneCount(1, 20)).
+			Else(v.Zero[string]()).
+			Cond(`type is "company"`),
+		v.F("note", ""): v.When(false, v.RuneCount(1, 10)).Cond("notes are enabled"),
+		v.F("memo", ""): v.When(false, v.RuneCount(1, 10)),
+	}
+
+	got, err := json.Marshal(jsonschema.ExportWithOptions(validator, jsonschema.Options{}))
+	if err != nil {
+		t.Fatalf("Marshal err: %v", err)
+	}
+
+	want := `{"properties":{` +
+		`"memo":{"anyOf":[{"maxLength":10,"minLength":1},true],` +
+		`"description":"Validated by the first schema of anyOf if an unspecified condition holds, or by the second one otherwise.","type":"string"},` +
+		`"note":{"anyOf":[{"maxLength":10,"minLength":1},true],` +
+		`"description":"Validated by the first schema of anyOf if notes are enabled, or by the second one otherwise.","type":"string"},` +
+		`"type":{"enum":["person","company"],"type":"string"},` +
+		`"vat_id":{"anyOf":[{"maxLength":20,"minLength":1},{"const":""}],` +
+		`"description":"Validated by the first schema of anyOf if type is \"company\", or by the second one otherwise.","type":"string"}` +
+		`},"type":"object"}`
+	if string(got) != want {
+		t.Errorf("Got (%s) != Want (%s)", got, want)
+	}
+}
+
+func TestExport_Group(t *testing.T) {
+	type Contact struct {
+		Email  string
+		Phone  string
+		Fax    string
+		Street string
+		City   string
+	}
+	c := Contact{}
+	email, phone, fax := v.F("email", c.Email), v.F("phone", c.Phone), v.F("fax", c.Fax)
+	street, city := v.F("street", c.Street), v.F("city", c.City)
+	validator := v.Schema{
+		email: v.ZeroOr[string](v.Match(`@`)),
+		v.F("", nil): v.All(
+			v.AtLeastOneOf(email, phone),
+			v.MutuallyExclusive(phone, fax),
+			v.RequiredWith(street, city),
+		),
+	}
+
+	got, err := json.Marshal(jsonschema.ExportWithOptions(validator, jsonschema.Options{}))
+	if err != nil {
+		t.Fatalf("Marshal err: %v", err)
+	}
+
+	want := `{"anyOf":[{"required":["email"]},{"required":["phone"]}],` +
+		`"dependentRequired":{"street":["city"]},` +
+		`"not":{"anyOf":[{"required":["phone","fax"]}]},` +
+		`"properties":{"email":{"anyOf":[{"const":""},{"pattern":"@"}],"type":"string"}},` +
+		`"type":"object"}`
+	if string(got) != want {
+		t.Errorf("Got (%s) != Want (%s)", got, want)
+	}
+}
+
+func TestExport_Required_Optional(t *testing.T) {
+	type Patch struct {
+		Name   v.Opt[string]
+		Age    *int
+		Email  sql.NullString
+		Active v.Opt[bool]
+	}
+	p := Patch{}
+	validator := v.Schema{
+		v.F("name", p.Name):     v.Required[string](v.RuneCount(1, 10)),
+		v.F("age", p.Age):       v.Optional[int](v.Gte(0)),
+		v.F("email", p.Email):   v.Optional[string](v.Match(`@`)),
+		v.F("active", p.Active): v.Required[bool](),
+	}
+
+	got, err := json.Marshal(jsonschema.ExportWithOptions(validator, jsonschema.Options{}))
+	if err != nil {
+		t.Fatalf("Marshal err: %v", err)
+	}
+
+	want := `{"properties":{` +
+		`"active":{"type":"boolean"},` +
+		`"age":{"minimum":0,"type":"integer"},` +
+		`"email":{"pattern":"@","type":"string"},` +
+		`"name":{"maxLength":10,"minLength":1,"type":"string"}` +
+		`},` +
+		`"required":["active","name"],` +
+		`"type":"object"}`
+	if string(got) != want {
+		t.Errorf("Got (%s) != Want (%s)", got, want)
+	}
+}
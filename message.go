@@ -0,0 +1,222 @@
+package validating
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Translator is an interface for translating message templates.
+type Translator interface {
+	// Translate returns the message template identified by key for the
+	// given locale. The key is either the code of a validator (for default
+	// messages), or the message set by MessageValidator.Msg.
+	Translate(locale, key string) (string, bool)
+}
+
+// Catalog is a Translator, which holds message templates keyed by locale
+// and key.
+//
+// To support a new language, register the translations of the default
+// messages (keyed by the validator codes) and the custom messages (keyed
+// by the messages themselves) for the corresponding locale:
+//
+//	v.DefaultCatalog.Register("de", map[string]string{
+//		"len_string":      "muss zwischen {min} und {max} Zeichen lang sein",
+//		"bad name length": "ungültige Namenslänge",
+//	})
+type Catalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string // locale => key => template
+}
+
+// NewCatalog creates an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{messages: make(map[string]map[string]string)}
+}
+
+// Register adds the given message templates for the given locale. Existing
+// templates with the same keys will be overwritten.
+func (c *Catalog) Register(locale string, messages map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m, ok := c.messages[locale]
+	if !ok {
+		m = make(map[string]string, len(messages))
+		c.messages[locale] = m
+	}
+	for key, msg := range messages {
+		m[key] = msg
+	}
+}
+
+// Translate returns the message template identified by key for the given
+// locale. If there is no such template, the base language of locale (e.g.
+// "zh" for "zh-CN") will be tried.
+func (c *Catalog) Translate(locale, key string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if msg, ok := c.messages[locale][key]; ok {
+		return msg, true
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		msg, ok := c.messages[locale[:i]][key]
+		return msg, ok
+	}
+	return "", false
+}
+
+// defaultMessages holds the default messages of the built-in validators,
+// keyed by the validator codes.
+var defaultMessages = map[string]string{
+	"not":        "is invalid",
+	"is":         "is invalid",
+	"nonzero":    "is zero valued",
+	"zero":       "is nonzero",
+	"len_string": "has an invalid length",
+	"len_slice":  "has an invalid length",
+	"rune_count": "the number of runes is not between the given range",
+	"eq":         "does not equal the given value",
+	"ne":         "equals the given value",
+	"gt":         "is lower than or equal to the given value",
+	"gte":        "is lower than the given value",
+	"lt":         "is greater than or equal to the given value",
+	"lte":        "is greater than the given value",
+	"range":      "is not between the given range",
+	"in":         "is not one of the given values",
+	"nin":        "is one of the given values",
+	"match":      "does not match the given regular expression",
+	"before":     "is not before the given time",
+	"after":      "is not after the given time",
+
+	"eq_field":     "does not equal {field}",
+	"ne_field":     "equals {field}",
+	"gt_field":     "is lower than or equal to {field}",
+	"gte_field":    "is lower than {field}",
+	"lt_field":     "is greater than or equal to {field}",
+	"lte_field":    "is greater than {field}",
+	"before_field": "is not before {field}",
+	"after_field":  "is not after {field}",
+
+	"exactly_one_of":     "exactly one of {fields} is required",
+	"at_least_one_of":    "at least one of {fields} is required",
+	"mutually_exclusive": "at most one of {fields} is allowed",
+	"required_with":      "{fields} are required when {field} is present",
+
+	"required":      "is required",
+	"unknown_field": "is not allowed",
+	"type":          "must be of type {expected}",
+	"format":        "has an invalid format",
+	"layout":        "must be a time formatted as {layout}",
+}
+
+// DefaultCatalog is the default Translator, which has the English ("en")
+// messages bundled.
+var DefaultCatalog = func() *Catalog {
+	c := NewCatalog()
+	c.Register("en", defaultMessages)
+	return c
+}()
+
+// ParseAcceptLanguage parses the value of an Accept-Language header, and
+// returns the locales in order of preference, which can be passed to
+// WithLocale.
+func ParseAcceptLanguage(header string) []string {
+	type locale struct {
+		tag string
+		q   float64
+	}
+
+	var locales []locale
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			f, err := strconv.ParseFloat(params[len("q="):], 64)
+			if err != nil || f <= 0 {
+				continue
+			}
+			q = f
+		}
+		locales = append(locales, locale{tag: tag, q: q})
+	}
+
+	sort.SliceStable(locales, func(i, j int) bool {
+		return locales[i].q > locales[j].q
+	})
+
+	tags := make([]string, len(locales))
+	for i, l := range locales {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// renderMessage renders the message template msg by replacing each
+// placeholder of the form {name} with the corresponding parameter in params,
+// or with the offending value if name is "value".
+//
+// Placeholders without corresponding parameters are kept as is, thus static
+// messages are rendered unchanged.
+func renderMessage(msg string, params map[string]any, value any) string {
+	if !strings.Contains(msg, "{") {
+		return msg
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(msg, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(msg[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		name := msg[start+1 : end]
+		param, ok := params[name]
+		if !ok && name == "value" {
+			param, ok = value, true
+		}
+
+		if ok {
+			b.WriteString(msg[:start])
+			b.WriteString(formatParam(param))
+		} else {
+			b.WriteString(msg[:end+1])
+		}
+		msg = msg[end+1:]
+	}
+	b.WriteString(msg)
+
+	return b.String()
+}
+
+// formatParam formats a parameter for human-readable messages.
+func formatParam(param any) string {
+	switch p := param.(type) {
+	case []any:
+		strs := make([]string, len(p))
+		for i, v := range p {
+			strs[i] = formatParam(v)
+		}
+		return strings.Join(strs, ", ")
+	case []string:
+		return strings.Join(p, ", ")
+	case []byte:
+		return string(p)
+	default:
+		return fmt.Sprintf("%v", p)
+	}
+}
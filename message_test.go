@@ -0,0 +1,161 @@
+package validating_test
+
+import (
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestMessageTemplate(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     any
+		validator v.Validator
+		msg       string
+	}{
+		{
+			name:      "static",
+			value:     "",
+			validator: v.LenString(1, 5).Msg("bad length"),
+			msg:       "bad length",
+		},
+		{
+			name:      "params",
+			value:     "",
+			validator: v.LenString(1, 5).Msg("must be between {min} and {max} characters"),
+			msg:       "must be between 1 and 5 characters",
+		},
+		{
+			name:      "value and values",
+			value:     "c",
+			validator: v.In("a", "b").Msg("{value} is not one of {values}"),
+			msg:       "c is not one of a, b",
+		},
+		{
+			name:      "bytes value",
+			value:     []byte("abc"),
+			validator: v.Match(`^\d+$`).Msg("{value} does not match {pattern}"),
+			msg:       `abc does not match ^\d+$`,
+		},
+		{
+			name:      "unknown placeholder",
+			value:     0,
+			validator: v.Gte(1).Msg("{value} is lower than {gte} {unknown}"),
+			msg:       "0 is lower than 1 {unknown}",
+		},
+		{
+			name:      "unclosed placeholder",
+			value:     0,
+			validator: v.Nonzero[int]().Msg("{value} is {zero"),
+			msg:       "0 is {zero",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(v.Value(c.value, c.validator))
+			if len(errs) != 1 {
+				t.Fatalf("Got %d errors != Want 1 error", len(errs))
+			}
+			if msg := errs[0].Message(); msg != c.msg {
+				t.Errorf("Got message (%s) != Want message (%s)", msg, c.msg)
+			}
+		})
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	catalog := v.NewCatalog()
+	catalog.Register("en", map[string]string{
+		"len_string": "must be between {min} and {max} characters",
+	})
+	catalog.Register("de", map[string]string{
+		"len_string":      "muss zwischen {min} und {max} Zeichen lang sein",
+		"bad name length": "ungültige Namenslänge",
+	})
+
+	cases := []struct {
+		name      string
+		validator v.Validator
+		opts      []v.Option
+		msg       string
+	}{
+		{
+			name:      "no locale",
+			validator: v.LenString(1, 5),
+			opts:      []v.Option{v.WithTranslator(catalog)},
+			msg:       "has an invalid length",
+		},
+		{
+			name:      "default catalog",
+			validator: v.LenString(1, 5),
+			opts:      []v.Option{v.WithLocale("en")},
+			msg:       "has an invalid length",
+		},
+		{
+			name:      "default message",
+			validator: v.LenString(1, 5),
+			opts:      []v.Option{v.WithLocale("de"), v.WithTranslator(catalog)},
+			msg:       "muss zwischen 1 und 5 Zeichen lang sein",
+		},
+		{
+			name:      "custom message",
+			validator: v.LenString(1, 5).Msg("bad name length"),
+			opts:      []v.Option{v.WithLocale("de"), v.WithTranslator(catalog)},
+			msg:       "ungültige Namenslänge",
+		},
+		{
+			name:      "untranslated custom message",
+			validator: v.LenString(1, 5).Msg("too long"),
+			opts:      []v.Option{v.WithLocale("de"), v.WithTranslator(catalog)},
+			msg:       "too long",
+		},
+		{
+			name:      "base language",
+			validator: v.LenString(1, 5),
+			opts:      []v.Option{v.WithLocale("de-AT"), v.WithTranslator(catalog)},
+			msg:       "muss zwischen 1 und 5 Zeichen lang sein",
+		},
+		{
+			name:      "fallback locale",
+			validator: v.LenString(1, 5),
+			opts:      []v.Option{v.WithLocale("fr", "en"), v.WithTranslator(catalog)},
+			msg:       "must be between 1 and 5 characters",
+		},
+		{
+			name:      "unsupported locale",
+			validator: v.LenString(1, 5),
+			opts:      []v.Option{v.WithLocale("fr"), v.WithTranslator(catalog)},
+			msg:       "has an invalid length",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(v.Value("", c.validator), c.opts...)
+			if len(errs) != 1 {
+				t.Fatalf("Got %d errors != Want 1 error", len(errs))
+			}
+			if msg := errs[0].Message(); msg != c.msg {
+				t.Errorf("Got message (%s) != Want message (%s)", msg, c.msg)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := []struct {
+		header  string
+		locales []string
+	}{
+		{"", []string{}},
+		{"de", []string{"de"}},
+		{"fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5", []string{"fr-CH", "fr", "en", "de"}},
+		{"en;q=0.5, zh-CN, ja;q=0", []string{"zh-CN", "en"}},
+	}
+	for _, c := range cases {
+		locales := v.ParseAcceptLanguage(c.header)
+		if !reflect.DeepEqual(locales, c.locales) {
+			t.Errorf("Got (%v) != Want (%v)", locales, c.locales)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package validating
+
+// Property is a key of an object, along with the validator of its value.
+type Property struct {
+	Key       string
+	Validator Validator
+
+	required bool
+}
+
+// Prop creates an optional property, whose value (if the key is present)
+// will be validated by validator. A nil validator accepts any value.
+func Prop(key string, validator Validator) *Property {
+	return &Property{Key: key, Validator: validator}
+}
+
+// Required marks the property as required, i.e. the key must be present.
+func (p *Property) Required() *Property {
+	p.required = true
+	return p
+}
+
+// ObjectValidator is a validator for dynamic objects of type map[string]any
+// (e.g. decoded from JSON), which allows users to change the policy for
+// additional properties by calling Strict() or Additional().
+type ObjectValidator struct {
+	props      []*Property
+	strict     bool      // Whether to disallow additional properties.
+	additional Validator // The validator for additional properties.
+}
+
+// Object is a composite validator factory used to create a validator, which
+// will validate a map[string]any field per the given properties.
+//
+// The properties are validated in their declaration order, and the
+// additional properties (if any) are validated in the order of their keys.
+// The field names of the errors follow the same convention as Schema (e.g.
+// "object.key", or "object.array[0].key" if used along with EachSlice).
+func Object(props ...*Property) *ObjectValidator {
+	return &ObjectValidator{props: props}
+}
+
+// Strict disallows additional properties, i.e. the keys other than the
+// declared ones.
+func (ov *ObjectValidator) Strict() *ObjectValidator {
+	ov.strict = true
+	return ov
+}
+
+// Additional makes the additional properties validated by validator.
+func (ov *ObjectValidator) Additional(validator Validator) *ObjectValidator {
+	ov.additional = validator
+	return ov
+}
+
+// Validate validates the object per its properties.
+func (ov *ObjectValidator) Validate(field *Field) Errors {
+	m, ok := field.Value.(map[string]any)
+	if !ok {
+		return typeMismatch("object").Invalid(field)
+	}
+
+	var s OrderedSchema
+	declared := make(map[string]bool, len(ov.props))
+	for _, p := range ov.props {
+		declared[p.Key] = true
+
+		value, ok := m[p.Key]
+		switch {
+		case !ok && p.required:
+			s = s.Add(F(p.Key, nil), missing())
+		case ok && p.Validator != nil:
+			s = s.Add(F(p.Key, value), p.Validator)
+		}
+	}
+
+	if ov.strict || ov.additional != nil {
+		for _, k := range sortedKeys(m) {
+			if declared[k.key] {
+				continue
+			}
+			switch {
+			case ov.strict:
+				s = s.Add(F(k.key, m[k.key]), notAllowed())
+			default:
+				s = s.Add(F(k.key, m[k.key]), ov.additional)
+			}
+		}
+	}
+
+	return s.Validate(field)
+}
+
+// missing creates a validator for reporting missing required values.
+func missing() *MessageValidator {
+	return Fail("required", nil)
+}
+
+// notAllowed creates a validator for reporting disallowed values.
+func notAllowed() *MessageValidator {
+	return Fail("unknown_field", nil)
+}
+
+// typeMismatch creates a validator for reporting dynamic values with
+// unexpected types (named as in JSON).
+func typeMismatch(expected string) *MessageValidator {
+	return Fail("type", map[string]any{"expected": expected})
+}
@@ -0,0 +1,120 @@
+package validating_test
+
+import (
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestObject(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     any
+		validator v.Validator
+		errs      v.Errors
+	}{
+		{
+			name:      "not an object",
+			value:     []any{},
+			validator: v.Object(),
+			errs: v.Errors{
+				v.NewError("payload", v.ErrInvalid, "must be of type object"),
+			},
+		},
+		{
+			name: "valid",
+			value: map[string]any{
+				"x": "foo",
+				"y": "ab",
+				"z": 1.0,
+			},
+			validator: v.Object(
+				v.Prop("x", v.Nonzero[string]()).Required(),
+				v.Prop("y", v.LenString(1, 5)),
+			),
+			errs: nil,
+		},
+		{
+			name: "invalid",
+			value: map[string]any{
+				"y": "abcdef",
+				"z": 1.0,
+				"a": true,
+			},
+			validator: v.Object(
+				v.Prop("x", v.Nonzero[string]()).Required(),
+				v.Prop("y", v.LenString(1, 5)),
+				v.Prop("w", v.LenString(1, 5)),
+			).Strict(),
+			errs: v.Errors{
+				v.NewError("payload.x", v.ErrInvalid, "is required"),
+				v.NewError("payload.y", v.ErrInvalid, "has an invalid length"),
+				v.NewError("payload.a", v.ErrInvalid, "is not allowed"),
+				v.NewError("payload.z", v.ErrInvalid, "is not allowed"),
+			},
+		},
+		{
+			name: "additional",
+			value: map[string]any{
+				"x": "foo",
+				"a": "",
+				"b": "bar",
+			},
+			validator: v.Object(
+				v.Prop("x", nil),
+			).Additional(v.Nonzero[string]()),
+			errs: v.Errors{
+				v.NewError("payload.a", v.ErrInvalid, "is zero valued"),
+			},
+		},
+		{
+			name: "nested",
+			value: map[string]any{
+				"owner": map[string]any{},
+				"items": []any{
+					map[string]any{"name": "a"},
+					map[string]any{"name": 1.0},
+					"b",
+				},
+			},
+			validator: v.Object(
+				v.Prop("owner", v.Object(
+					v.Prop("name", nil).Required(),
+				)),
+				v.Prop("items", v.EachSlice[[]any](v.Object(
+					v.Prop("name", v.LenString(1, 5)).Required(),
+				))),
+			),
+			errs: v.Errors{
+				v.NewError("payload.owner.name", v.ErrInvalid, "is required"),
+				v.NewError("payload.items[1].name", v.ErrUnsupported, "LenString expected string but got float64"),
+				v.NewError("payload.items[2]", v.ErrInvalid, "must be of type object"),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(v.Schema{
+				v.F("payload", c.value): c.validator,
+			})
+			if !reflect.DeepEqual(plainErrs(errs), c.errs) {
+				t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+			}
+		})
+	}
+}
+
+func TestObject_CodedError(t *testing.T) {
+	errs := v.Validate(v.Value(map[string]any{"y": 1}, v.Object(
+		v.Prop("x", nil).Required(),
+	).Strict()))
+
+	want := v.Errors{
+		v.NewCodedError("x", v.ErrInvalid, "is required", "required", nil),
+		v.NewCodedError("y", v.ErrInvalid, "is not allowed", "unknown_field", nil),
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Got (%+v) != Want (%+v)", errs, want)
+	}
+}
@@ -0,0 +1,94 @@
+// Package openapi generates OpenAPI 3.1 component schemas from the
+// validation schemas of Go types, so that the API specification is derived
+// from the same rules enforced at runtime.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/jsonschema"
+)
+
+// RefPrefix is the prefix of the references to component schemas.
+const RefPrefix = "#/components/schemas/"
+
+type component struct {
+	name  string
+	typ   reflect.Type
+	value any
+}
+
+// Generator generates component schemas for a set of named Go types.
+//
+// Since OpenAPI 3.1 is fully compatible with JSON Schema draft 2020-12,
+// the component schemas are exported by the jsonschema package. Fields of
+// the registered types are exported as references (e.g. {"$ref":
+// "#/components/schemas/Address"}) instead of inline schemas.
+type Generator struct {
+	components []component
+}
+
+// NewGenerator creates a generator.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Add registers the type of value, whose component schema will be
+// generated from the validation schema of value (see v.SchemaOf) and be
+// named as name. It panics if value defines no validation schema.
+//
+// The validation schema is typically obtained from the zero value of
+// the type, thus Nested functions should be able to handle zero values
+// (e.g. nil pointers).
+func (g *Generator) Add(name string, value any) *Generator {
+	if _, ok := v.SchemaOf(value); !ok {
+		panic(fmt.Sprintf("openapi: %T defines no validation schema", value))
+	}
+
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	g.components = append(g.components, component{name: name, typ: t, value: value})
+	return g
+}
+
+// Schemas returns the component schemas keyed by the type names.
+func (g *Generator) Schemas() map[string]any {
+	refs := make(map[reflect.Type]string, len(g.components))
+	for _, c := range g.components {
+		refs[c.typ] = RefPrefix + c.name
+	}
+
+	schemas := make(map[string]any, len(g.components))
+	for _, c := range g.components {
+		opts := jsonschema.Options{Refs: refs}
+		schema, _ := v.SchemaOf(c.value)
+		schemas[c.name] = jsonschema.ExportWithOptions(schema, opts)
+	}
+	return schemas
+}
+
+// Components returns the document fragment containing the component
+// schemas, i.e. {"components": {"schemas": {...}}}, which can be merged
+// into an OpenAPI document.
+func (g *Generator) Components() map[string]any {
+	return map[string]any{
+		"components": map[string]any{
+			"schemas": g.Schemas(),
+		},
+	}
+}
+
+// JSON returns the JSON encoding of g.Components().
+func (g *Generator) JSON() ([]byte, error) {
+	return json.MarshalIndent(g.Components(), "", "  ")
+}
+
+// YAML returns the YAML encoding of g.Components().
+func (g *Generator) YAML() ([]byte, error) {
+	return marshalYAML(g.Components())
+}
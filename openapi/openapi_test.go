@@ -0,0 +1,202 @@
+package openapi_test
+
+import (
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/openapi"
+)
+
+type Address struct {
+	Country string
+	City    string
+}
+
+func (a Address) Schema() v.Schema {
+	return v.Schema{
+		v.F("country", a.Country): v.Nonzero[string](),
+		v.F("city", a.City):       v.In("A", "B"),
+	}
+}
+
+type Person struct {
+	Name      string
+	Age       int
+	Address   Address
+	Addresses []*Address
+}
+
+func (p Person) Schema() v.OrderedSchema {
+	return v.OrderedSchema{}.
+		Add(v.F("name", p.Name), v.All(v.Nonzero[string](), v.RuneCount(1, 10), v.Match(`^\w+$`))).
+		Add(v.F("age", p.Age), v.Lte(150)).
+		Add(v.F("address", p.Address), p.Address.Schema()).
+		Add(v.F("addresses", p.Addresses), v.EachSlice[[]*Address](v.Nested(func(a *Address) v.Validator {
+			return a.Schema()
+		})))
+}
+
+func newGenerator() *openapi.Generator {
+	return openapi.NewGenerator().
+		Add("Address", Address{}).
+		Add("Person", Person{})
+}
+
+func TestGenerator_YAML(t *testing.T) {
+	got, err := newGenerator().YAML()
+	if err != nil {
+		t.Fatalf("YAML err: %v", err)
+	}
+
+	want := `components:
+  schemas:
+    Address:
+      properties:
+        city:
+          enum:
+            - "A"
+            - "B"
+          type: "string"
+        country:
+          minLength: 1
+          type: "string"
+      required:
+        - "country"
+      type: "object"
+    Person:
+      properties:
+        address:
+          $ref: "#/components/schemas/Address"
+        addresses:
+          items:
+            $ref: "#/components/schemas/Address"
+          type: "array"
+        age:
+          maximum: 150
+          type: "integer"
+        name:
+          maxLength: 10
+          minLength: 1
+          pattern: "^\\w+$"
+          type: "string"
+      required:
+        - "name"
+      type: "object"
+`
+	if string(got) != want {
+		t.Errorf("Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestGenerator_JSON(t *testing.T) {
+	got, err := openapi.NewGenerator().Add("Address", Address{}).JSON()
+	if err != nil {
+		t.Fatalf("JSON err: %v", err)
+	}
+
+	want := `{
+  "components": {
+    "schemas": {
+      "Address": {
+        "properties": {
+          "city": {
+            "enum": [
+              "A",
+              "B"
+            ],
+            "type": "string"
+          },
+          "country": {
+            "minLength": 1,
+            "type": "string"
+          }
+        },
+        "required": [
+          "country"
+        ],
+        "type": "object"
+      }
+    }
+  }
+}`
+	if string(got) != want {
+		t.Errorf("Got:\n%s\nWant:\n%s", got, want)
+	}
+}
+
+func TestGenerator_Add(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "openapi: int defines no validation schema" {
+			t.Errorf("Got (%v) != Want panic", r)
+		}
+	}()
+	openapi.NewGenerator().Add("Int", 0)
+}
+
+type Switch struct {
+	On, Off, Yes, No, Null, True, Tilde, Number string
+}
+
+func (s Switch) Schema() v.OrderedSchema {
+	return v.OrderedSchema{}.
+		Add(v.F("on", s.On), v.In("on", "ON")).
+		Add(v.F("Off", s.Off), v.Nonzero[string]()).
+		Add(v.F("yes", s.Yes), v.Eq("null")).
+		Add(v.F("NO", s.No), v.Nonzero[string]()).
+		Add(v.F("null", s.Null), v.Nonzero[string]()).
+		Add(v.F("true", s.True), v.Nonzero[string]()).
+		Add(v.F("~", s.Tilde), v.Nonzero[string]()).
+		Add(v.F("1e3", s.Number), v.Nonzero[string]())
+}
+
+func TestGenerator_YAML_QuotedKeys(t *testing.T) {
+	got, err := openapi.NewGenerator().Add("Switch", Switch{}).YAML()
+	if err != nil {
+		t.Fatalf("YAML err: %v", err)
+	}
+
+	// Keys, which YAML parsers may resolve to booleans, null or numbers,
+	// must be quoted.
+	want := `components:
+  schemas:
+    Switch:
+      properties:
+        "1e3":
+          minLength: 1
+          type: "string"
+        "NO":
+          minLength: 1
+          type: "string"
+        "Off":
+          minLength: 1
+          type: "string"
+        "null":
+          minLength: 1
+          type: "string"
+        "on":
+          enum:
+            - "on"
+            - "ON"
+          type: "string"
+        "true":
+          minLength: 1
+          type: "string"
+        "yes":
+          const: "null"
+          type: "string"
+        "~":
+          minLength: 1
+          type: "string"
+      required:
+        - "Off"
+        - "NO"
+        - "null"
+        - "true"
+        - "~"
+        - "1e3"
+      type: "object"
+`
+	if string(got) != want {
+		t.Errorf("Got:\n%s\nWant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// marshalYAML encodes value, which consists of maps with string keys,
+// slices and JSON-compatible scalars, as a YAML document.
+//
+// Mappings are written in block style with sorted keys, while strings are
+// always double-quoted (as in JSON) to avoid any ambiguity.
+func marshalYAML(value any) ([]byte, error) {
+	var b bytes.Buffer
+	if err := writeYAML(&b, value, 0); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// plainKeyPattern matches the keys that may be written without quotes.
+var plainKeyPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$.\-]*$`)
+
+// reservedKeys holds the (lower-cased) plain scalars that YAML parsers may
+// resolve to booleans or null, per either YAML 1.1 or YAML 1.2.
+var reservedKeys = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true,
+	"true": true, "false": true, "on": true, "off": true,
+	"null": true,
+}
+
+// plainKey reports whether key can be written without quotes, which is the
+// case if it's neither a reserved scalar nor something like a number.
+func plainKey(key string) bool {
+	return plainKeyPattern.MatchString(key) && !reservedKeys[strings.ToLower(key)]
+}
+
+func writeYAML(b *bytes.Buffer, value any, indent int) error {
+	rv := reflect.ValueOf(value)
+	switch {
+	case isBlock(rv) && rv.Kind() == reflect.Map:
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			if k.Kind() != reflect.String {
+				return fmt.Errorf("openapi: unsupported map key type %s", k.Type())
+			}
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			b.WriteString(strings.Repeat(" ", indent))
+			if plainKey(k) {
+				b.WriteString(k)
+			} else {
+				writeScalar(b, k)
+			}
+			b.WriteString(":")
+
+			if err := writeChild(b, rv.MapIndex(reflect.ValueOf(k)).Interface(), indent); err != nil {
+				return err
+			}
+		}
+	case isBlock(rv) && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array):
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i).Interface()
+			erv := reflect.ValueOf(elem)
+			if isBlock(erv) && erv.Kind() == reflect.Map {
+				// Write the first key on the same line as the dash.
+				var sub bytes.Buffer
+				if err := writeYAML(&sub, elem, indent+2); err != nil {
+					return err
+				}
+				b.WriteString(strings.Repeat(" ", indent) + "- ")
+				b.Write(sub.Bytes()[indent+2:])
+				continue
+			}
+
+			b.WriteString(strings.Repeat(" ", indent) + "-")
+			if err := writeChild(b, elem, indent); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := writeScalar(b, value); err != nil {
+			return err
+		}
+		b.WriteString("\n")
+	}
+	return nil
+}
+
+// writeChild writes value as the child of a mapping key or a sequence dash.
+func writeChild(b *bytes.Buffer, value any, indent int) error {
+	if isBlock(reflect.ValueOf(value)) {
+		b.WriteString("\n")
+		return writeYAML(b, value, indent+2)
+	}
+	b.WriteString(" ")
+	return writeYAML(b, value, indent)
+}
+
+// isBlock reports whether rv is a non-empty map or slice, which is written
+// in block style.
+func isBlock(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() > 0
+	default:
+		return false
+	}
+}
+
+// writeScalar writes value in flow style, which is valid in both JSON and
+// YAML (e.g. empty collections are written as {} and []).
+func writeScalar(b *bytes.Buffer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	b.Write(data)
+	return nil
+}
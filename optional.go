@@ -0,0 +1,168 @@
+package validating
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+)
+
+// Opt is a value of type T, which also records whether it's present. It's
+// useful for distinguishing a value that is not provided from a zero value
+// that is provided explicitly (e.g. false in the body of a PATCH request).
+type Opt[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Some returns a present Opt holding the given value.
+func Some[T any](value T) Opt[T] {
+	return Opt[T]{Value: value, Set: true}
+}
+
+// Get returns the value, and reports whether it's present.
+func (o Opt[T]) Get() (T, bool) {
+	return o.Value, o.Set
+}
+
+// MarshalJSON implements json.Marshaler. An absent value is encoded as null.
+func (o Opt[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A value decoded from JSON is
+// present unless it's null.
+func (o *Opt[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Opt[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.Value); err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
+
+// Required is a composite validator factory used to create a validator, which
+// will succeed when the field's value is present, and all the given validators
+// succeed on the value.
+//
+// Unlike Nonzero, Required only treats missing values as absent, which can be
+// represented by nil, a value of type *T (nil), Opt[T] (not set), or one of
+// the sql.Null* types holding T (not valid). Any other value of type T is
+// always present.
+// The given validators are applied to the value of type T.
+func Required[T any](validators ...Validator) (mv *MessageValidator) {
+	mv = &MessageValidator{
+		Message: "is required",
+		Code:    "required",
+		Validator: Func(func(field *Field) Errors {
+			v, present, ok := presence[T](field.Value)
+			if !ok {
+				var want T
+				return NewUnsupportedErrors("Required", field, want, &want, Opt[T]{})
+			}
+
+			if !present {
+				return mv.Invalid(field)
+			}
+			return validatePresent(v, field, validators)
+		}),
+		validators: validators,
+	}
+	return
+}
+
+// Optional is a composite validator factory used to create a validator, which
+// will succeed when the field's value is absent, or when all the given
+// validators succeed on the value.
+//
+// The field's value is handled in the same way as in Required.
+func Optional[T any](validators ...Validator) Validator {
+	return describedFunc{
+		Func: func(field *Field) Errors {
+			v, present, ok := presence[T](field.Value)
+			if !ok {
+				var want T
+				return NewUnsupportedErrors("Optional", field, want, &want, Opt[T]{})
+			}
+
+			if !present {
+				return nil
+			}
+			return validatePresent(v, field, validators)
+		},
+		describe: func() Description {
+			return Description{Code: "optional", Validators: describeAll(validators...)}
+		},
+	}
+}
+
+// validatePresent validates the present value of field by using validators.
+func validatePresent(value any, field *Field, validators []Validator) Errors {
+	if len(validators) == 0 {
+		return nil
+	}
+	f := &Field{Name: field.Name, Value: value, run: field.run}
+	return validate(All(validators...), f)
+}
+
+// presence returns the value held by value, which is of type T, *T, Opt[T]
+// or one of the sql.Null* types holding T, and reports whether it's present.
+// It reports false for ok if value is of any other type.
+//
+// A nil value is absent. The wrappers are checked before T itself, which
+// would match them if T is an interface type (e.g. any).
+func presence[T any](value any) (v T, present, ok bool) {
+	switch x := value.(type) {
+	case nil:
+		return v, false, true
+	case *T:
+		if x == nil {
+			return v, false, true
+		}
+		return *x, true, true
+	case Opt[T]:
+		return x.Value, x.Set, true
+	}
+
+	if inner, valid, ok := sqlNull(value); ok {
+		if inner, ok := inner.(T); ok {
+			return inner, valid, true
+		}
+	}
+
+	if x, ok := value.(T); ok {
+		return x, true, true
+	}
+	return v, false, false
+}
+
+// sqlNull returns the value held by value, which is one of the sql.Null*
+// types, and reports whether it's valid. It reports false for ok if value
+// is of any other type.
+func sqlNull(value any) (v any, valid, ok bool) {
+	switch x := value.(type) {
+	case sql.NullString:
+		return x.String, x.Valid, true
+	case sql.NullBool:
+		return x.Bool, x.Valid, true
+	case sql.NullByte:
+		return x.Byte, x.Valid, true
+	case sql.NullInt16:
+		return x.Int16, x.Valid, true
+	case sql.NullInt32:
+		return x.Int32, x.Valid, true
+	case sql.NullInt64:
+		return x.Int64, x.Valid, true
+	case sql.NullFloat64:
+		return x.Float64, x.Valid, true
+	case sql.NullTime:
+		return x.Time, x.Valid, true
+	default:
+		return nil, false, false
+	}
+}
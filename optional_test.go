@@ -0,0 +1,204 @@
+package validating_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestRequired_Optional(t *testing.T) {
+	f := false
+	required := v.NewCodedError("value", v.ErrInvalid, "is required", "required", nil)
+
+	cases := []struct {
+		name      string
+		value     any
+		validator v.Validator
+		errs      v.Errors
+	}{
+		{
+			name:      "required value",
+			value:     false,
+			validator: v.Required[bool](),
+			errs:      nil,
+		},
+		{
+			name:      "required nil pointer",
+			value:     (*bool)(nil),
+			validator: v.Required[bool](),
+			errs:      v.Errors{required},
+		},
+		{
+			name:      "required zero pointer",
+			value:     &f,
+			validator: v.Required[bool](),
+			errs:      nil,
+		},
+		{
+			name:      "required unset opt",
+			value:     v.Opt[bool]{},
+			validator: v.Required[bool](),
+			errs:      v.Errors{required},
+		},
+		{
+			name:      "required set opt",
+			value:     v.Some(false),
+			validator: v.Required[bool](v.Eq(false)),
+			errs:      nil,
+		},
+		{
+			name:      "required invalid sql null",
+			value:     sql.NullString{},
+			validator: v.Required[string](),
+			errs:      v.Errors{required},
+		},
+		{
+			name:      "required valid sql null",
+			value:     sql.NullString{String: "abc", Valid: true},
+			validator: v.Required[string](v.LenString(1, 2)),
+			errs: v.Errors{
+				v.NewCodedError("value", v.ErrInvalid, "has an invalid length", "len_string", map[string]any{"min": 1, "max": 2}),
+			},
+		},
+		{
+			name:      "required with custom message",
+			value:     v.Opt[int]{},
+			validator: v.Required[int]().Msg("missing"),
+			errs: v.Errors{
+				v.NewCodedError("value", v.ErrInvalid, "missing", "required", nil),
+			},
+		},
+		{
+			name:      "required unsupported",
+			value:     sql.NullInt64{},
+			validator: v.Required[string](),
+			errs:      v.NewErrors("value", v.ErrUnsupported, "Required expected string or *string or validating.Opt[string] but got sql.NullInt64"),
+		},
+		{
+			name:      "required nil",
+			value:     nil,
+			validator: v.Required[string](),
+			errs:      v.Errors{required},
+		},
+		{
+			name:      "required any unset opt",
+			value:     v.Opt[any]{},
+			validator: v.Required[any](),
+			errs:      v.Errors{required},
+		},
+		{
+			name:      "required any nil pointer",
+			value:     (*any)(nil),
+			validator: v.Required[any](),
+			errs:      v.Errors{required},
+		},
+		{
+			name:      "required any invalid sql null",
+			value:     sql.NullInt64{},
+			validator: v.Required[any](),
+			errs:      v.Errors{required},
+		},
+		{
+			name:      "required any value",
+			value:     0,
+			validator: v.Required[any](v.Is(func(x any) bool { return x == 0 })),
+			errs:      nil,
+		},
+		{
+			name:      "required sql null itself",
+			value:     sql.NullString{},
+			validator: v.Required[sql.NullString](),
+			errs:      nil,
+		},
+		{
+			name:      "optional absent",
+			value:     (*int)(nil),
+			validator: v.Optional[int](v.Gte(10)),
+			errs:      nil,
+		},
+		{
+			name:      "optional unset opt",
+			value:     v.Opt[int]{},
+			validator: v.Optional[int](v.Gte(10)),
+			errs:      nil,
+		},
+		{
+			name:      "optional present",
+			value:     v.Some(0),
+			validator: v.Optional[int](v.Gte(10)),
+			errs: v.Errors{
+				v.NewCodedError("value", v.ErrInvalid, "is lower than the given value", "gte", map[string]any{"gte": 10}),
+			},
+		},
+		{
+			name:      "optional valid sql null",
+			value:     sql.NullInt64{Int64: 20, Valid: true},
+			validator: v.Optional[int64](v.Gte[int64](10)),
+			errs:      nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(v.Schema{
+				v.F("value", c.value): c.validator,
+			})
+			if !reflect.DeepEqual(errs, c.errs) {
+				t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+			}
+		})
+	}
+}
+
+func TestOpt_JSON(t *testing.T) {
+	type Patch struct {
+		Name   v.Opt[string] `json:"name"`
+		Active v.Opt[bool]   `json:"active"`
+	}
+
+	cases := []struct {
+		in   string
+		want Patch
+		out  string
+	}{
+		{
+			in:   `{}`,
+			want: Patch{},
+			out:  `{"name":null,"active":null}`,
+		},
+		{
+			in:   `{"name":null,"active":false}`,
+			want: Patch{Active: v.Some(false)},
+			out:  `{"name":null,"active":false}`,
+		},
+		{
+			in:   `{"name":"foo","active":true}`,
+			want: Patch{Name: v.Some("foo"), Active: v.Some(true)},
+			out:  `{"name":"foo","active":true}`,
+		},
+	}
+	for _, c := range cases {
+		var p Patch
+		if err := json.Unmarshal([]byte(c.in), &p); err != nil {
+			t.Fatalf("Unmarshal err: %v", err)
+		}
+		if !reflect.DeepEqual(p, c.want) {
+			t.Errorf("Got (%+v) != Want (%+v)", p, c.want)
+		}
+
+		out, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("Marshal err: %v", err)
+		}
+		if string(out) != c.out {
+			t.Errorf("Got (%s) != Want (%s)", out, c.out)
+		}
+	}
+
+	var p Patch
+	if err := json.Unmarshal([]byte(`{"active":"yes"}`), &p); err == nil {
+		t.Errorf("Unmarshal err: want non-nil error")
+	}
+}
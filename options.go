@@ -0,0 +1,210 @@
+package validating
+
+import (
+	"context"
+	"strings"
+)
+
+// Options holds the options of a validation run.
+type Options struct {
+	// MaxErrors is the maximum number of errors to report. Once it's reached,
+	// the rest of the validation (including the nested ones) will be
+	// short-circuited. Zero means no limit.
+	MaxErrors int
+
+	// Locales are the preferred locales of the error messages, in order of
+	// preference. No locale means no translation at all.
+	Locales []string
+
+	// Translator is used to translate the error messages. Defaults to
+	// DefaultCatalog.
+	Translator Translator
+
+	// PanicOnUnsupported makes the validation panic with *UnsupportedError
+	// if the errors found contain any of kind ErrUnsupported.
+	PanicOnUnsupported bool
+
+	// Fields are the paths of the fields to validate (e.g. "address.city").
+	// Nil means all fields.
+	Fields []string
+}
+
+// Option is used to customize a validation run.
+type Option func(*Options)
+
+// WithMaxErrors makes the validation stop after n errors have been found.
+func WithMaxErrors(n int) Option {
+	return func(o *Options) {
+		o.MaxErrors = n
+	}
+}
+
+// WithFailFast makes the validation stop at the first error.
+func WithFailFast() Option {
+	return WithMaxErrors(1)
+}
+
+// WithLocale makes the error messages translated into the first of the
+// given locales that is supported by the translator.
+func WithLocale(locales ...string) Option {
+	return func(o *Options) {
+		o.Locales = locales
+	}
+}
+
+// WithTranslator sets the translator used to translate the error messages.
+func WithTranslator(t Translator) Option {
+	return func(o *Options) {
+		o.Translator = t
+	}
+}
+
+// WithPanicOnUnsupported makes the validation panic with *UnsupportedError
+// if the errors found contain any of kind ErrUnsupported, which surfaces the
+// programming mistakes early (e.g. in tests or development).
+//
+// Only the final errors are checked, thus errors discarded by composite
+// validators (e.g. by Any when another alternative succeeds) never panic.
+func WithPanicOnUnsupported() Option {
+	return func(o *Options) {
+		o.PanicOnUnsupported = true
+	}
+}
+
+// WithFields makes the validation apply only to the fields with the given
+// paths (e.g. the keys of a JSON merge patch), which is useful for partial
+// updates.
+//
+// A field of a schema is validated if its path equals one of the given
+// paths, or is an ancestor (e.g. "address" for "address.city") or a
+// descendant (e.g. "tags[0]" for "tags") of one of them. Fields without
+// names, such as the ones created by Value, are always validated. The
+// validators of the other fields are skipped, unless they are marked by
+// Always.
+func WithFields(paths ...string) Option {
+	return func(o *Options) {
+		o.Fields = append([]string{}, paths...)
+	}
+}
+
+// run holds the state of a validation run.
+//
+// A nil *run is valid, which represents a run with the default options.
+// This is the case when a validator is invoked directly with a field
+// created by F.
+type run struct {
+	ctx  context.Context
+	opts Options
+	n    int // The number of errors found so far.
+
+	always int // The depth of the validators marked by Always.
+}
+
+func newRun(ctx context.Context, opts []Option) *run {
+	r := &run{ctx: ctx}
+	for _, o := range opts {
+		o(&r.opts)
+	}
+	return r
+}
+
+// count returns the number of errors found so far.
+func (r *run) count() int {
+	if r == nil {
+		return 0
+	}
+	return r.n
+}
+
+// setCount resets the number of errors found so far.
+func (r *run) setCount(n int) {
+	if r != nil {
+		r.n = n
+	}
+}
+
+// stopped reports whether the rest of the validation should be skipped.
+func (r *run) stopped() bool {
+	if r == nil {
+		return false
+	}
+	if r.ctx.Err() != nil {
+		return true
+	}
+	return r.opts.MaxErrors > 0 && r.n >= r.opts.MaxErrors
+}
+
+// check panics if errs contain errors of kind ErrUnsupported and the run is
+// configured to do so.
+func (r *run) check(errs Errors) {
+	if !r.opts.PanicOnUnsupported {
+		return
+	}
+	if unsupported := errs.filter(ErrUnsupported); unsupported != nil {
+		panic(&UnsupportedError{Errors: unsupported})
+	}
+}
+
+// selected reports whether the field with the given name should be
+// validated.
+func (r *run) selected(name string) bool {
+	if r == nil || r.opts.Fields == nil || r.always > 0 || name == "" {
+		return true
+	}
+	for _, path := range r.opts.Fields {
+		if covers(path, name) || covers(name, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// unfiltered calls f with all fields selected.
+func (r *run) unfiltered(f func() Errors) Errors {
+	if r == nil {
+		return f()
+	}
+	r.always++
+	defer func() { r.always-- }()
+	return f()
+}
+
+// covers reports whether path equals name or is an ancestor of it.
+func covers(path, name string) bool {
+	if !strings.HasPrefix(name, path) {
+		return false
+	}
+	rest := name[len(path):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
+
+// translate returns the message template identified by key for the
+// preferred locales.
+func (r *run) translate(key string) (string, bool) {
+	if r == nil || len(r.opts.Locales) == 0 {
+		return "", false
+	}
+
+	t := r.opts.Translator
+	if t == nil {
+		t = DefaultCatalog
+	}
+	for _, locale := range r.opts.Locales {
+		if msg, ok := t.Translate(locale, key); ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// finish post-processes the errors collected in the run.
+func (r *run) finish(errs Errors) Errors {
+	if max := r.opts.MaxErrors; max > 0 && len(errs) > max {
+		errs = errs[:max]
+	}
+	if err := r.ctx.Err(); err != nil {
+		errs.Append(NewError("", ErrCanceled, err.Error()))
+	}
+	r.check(errs)
+	return errs
+}
@@ -0,0 +1,122 @@
+// Package problem converts validation errors into problem details
+// for HTTP APIs, as defined in RFC 7807.
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+// ContentType is the media type of a problem details document.
+const ContentType = "application/problem+json"
+
+// InvalidParam describes a parameter (i.e. a field) that failed the
+// validation. It's the element of the "invalid-params" extension member.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	Code   string `json:"code,omitempty"`
+}
+
+// Details is a problem details document.
+type Details struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// InvalidParams is an extension member, which holds the details
+	// of all invalid parameters.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// StatusClientClosedRequest is the (non-standard) status code used when the
+// client closes the request before the server responds.
+const StatusClientClosedRequest = 499
+
+// StatusText is like http.StatusText, but also knows the text of
+// StatusClientClosedRequest.
+func StatusText(code int) string {
+	if code == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(code)
+}
+
+// Status returns the HTTP status code for the given errors, which is:
+//
+//   - 200 (OK) if there is no error;
+//   - 500 (Internal Server Error) if there are any UNSUPPORTED errors (or
+//     errors of unknown kinds), since they indicate programming mistakes;
+//   - 503 (Service Unavailable) if the validation is stopped by a deadline,
+//     or StatusClientClosedRequest if it's canceled (e.g. when the client
+//     disconnects);
+//   - 422 (Unprocessable Entity) if all errors are INVALID errors.
+func Status(errs v.Errors) int {
+	if len(errs) == 0 {
+		return http.StatusOK
+	}
+
+	status := http.StatusUnprocessableEntity
+	for _, err := range errs {
+		switch err.Kind() {
+		case v.ErrInvalid:
+		case v.ErrCanceled:
+			status = StatusClientClosedRequest
+			if err.Message() == context.DeadlineExceeded.Error() {
+				status = http.StatusServiceUnavailable
+			}
+		default:
+			return http.StatusInternalServerError
+		}
+	}
+	return status
+}
+
+// New converts the given errors into a problem details document.
+//
+// Only INVALID errors are listed in the "invalid-params" member. If there
+// are any other errors, a document merely describing the status (see Status)
+// is returned instead, and no error details will be exposed to clients.
+//
+// Empty errors mean no problem at all, thus callers should not pass them.
+func New(errs v.Errors) *Details {
+	status := Status(errs)
+	d := &Details{
+		Title:  StatusText(status),
+		Status: status,
+	}
+	if status != http.StatusUnprocessableEntity {
+		return d
+	}
+
+	d.Detail = "The request parameters are invalid."
+	for _, err := range errs {
+		p := InvalidParam{
+			Name:   err.Field(),
+			Reason: err.Message(),
+		}
+		if ce, ok := err.(v.CodedError); ok {
+			p.Code = ce.Code()
+		}
+		d.InvalidParams = append(d.InvalidParams, p)
+	}
+	return d
+}
+
+// Write writes the problem details document converted from the given
+// errors to w, along with the corresponding status code.
+func Write(w http.ResponseWriter, errs v.Errors) error {
+	return New(errs).Write(w)
+}
+
+// Write writes d to w, along with the status code of d.
+func (d *Details) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(d.Status)
+	return json.NewEncoder(w).Encode(d)
+}
@@ -0,0 +1,111 @@
+package problem_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/problem"
+)
+
+func TestWrite(t *testing.T) {
+	cases := []struct {
+		name   string
+		errs   v.Errors
+		status int
+		body   string
+	}{
+		{
+			name: "invalid",
+			errs: v.Validate(v.OrderedSchema{}.
+				Add(v.F("name", ""), v.LenString(1, 5)).
+				Add(v.F("tags", []string{"x"}), v.EachSlice[[]string](v.In("a", "b").Msg("unknown tag"))),
+			),
+			status: http.StatusUnprocessableEntity,
+			body: `{"title":"Unprocessable Entity","status":422,"detail":"The request parameters are invalid.","invalid-params":[` +
+				`{"name":"name","reason":"has an invalid length","code":"len_string"},` +
+				`{"name":"tags[0]","reason":"unknown tag","code":"in"}` +
+				`]}` + "\n",
+		},
+		{
+			name: "unsupported",
+			errs: v.Validate(v.OrderedSchema{}.
+				Add(v.F("name", ""), v.LenString(1, 5)).
+				Add(v.F("age", int64(1)), v.Gte(10)),
+			),
+			status: http.StatusInternalServerError,
+			body:   `{"title":"Internal Server Error","status":500}` + "\n",
+		},
+		{
+			name: "canceled",
+			errs: v.Errors{
+				v.NewError("name", v.ErrInvalid, "has an invalid length"),
+				v.NewError("", v.ErrCanceled, context.Canceled.Error()),
+			},
+			status: problem.StatusClientClosedRequest,
+			body:   `{"title":"Client Closed Request","status":499}` + "\n",
+		},
+		{
+			name: "deadline exceeded",
+			errs: v.Errors{
+				v.NewError("", v.ErrCanceled, context.DeadlineExceeded.Error()),
+			},
+			status: http.StatusServiceUnavailable,
+			body:   `{"title":"Service Unavailable","status":503}` + "\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := problem.Write(w, c.errs); err != nil {
+				t.Fatalf("Write err: %v", err)
+			}
+
+			if w.Code != c.status {
+				t.Errorf("Got status (%d) != Want status (%d)", w.Code, c.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != problem.ContentType {
+				t.Errorf("Got Content-Type (%s) != Want Content-Type (%s)", ct, problem.ContentType)
+			}
+			if body := w.Body.String(); body != c.body {
+				t.Errorf("Got body (%s) != Want body (%s)", body, c.body)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		errs v.Errors
+		want int
+	}{
+		{
+			name: "no error",
+			errs: nil,
+			want: http.StatusOK,
+		},
+		{
+			name: "invalid",
+			errs: v.NewErrors("name", v.ErrInvalid, "is zero valued"),
+			want: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unsupported and canceled",
+			errs: v.Errors{
+				v.NewError("", v.ErrCanceled, context.Canceled.Error()),
+				v.NewError("age", v.ErrUnsupported, "Gte expected int but got int64"),
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := problem.Status(c.errs); got != c.want {
+				t.Errorf("Got (%d) != Want (%d)", got, c.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package validating
 
+import (
+	"context"
+)
+
 // Field represents a (Name, Value) pair that needs to be validated.
 type Field struct {
 	Name  string
 	Value any
+
+	run *run // The state of the validation run, which the field belongs to.
 }
 
 // F is a shortcut for creating a pointer to Field.
@@ -11,12 +17,102 @@ func F(name string, value any) *Field {
 	return &Field{Name: name, Value: value}
 }
 
+// Context returns the context of the validation run, which the field
+// belongs to. The returned context is never nil, it defaults to
+// context.Background().
+func (f *Field) Context() context.Context {
+	if f.run == nil || f.run.ctx == nil {
+		return context.Background()
+	}
+	return f.run.ctx
+}
+
 // Validator is an interface for representing a validating's validator.
 type Validator interface {
 	Validate(field *Field) Errors
 }
 
-// Validate invokes v.Validate with an empty field.
-func Validate(v Validator) (errs Errors) {
-	return v.Validate(&Field{})
+// Schemer is implemented by any type that defines its validation schema.
+//
+// Types whose Schema methods return Schema or OrderedSchema, which do not
+// implement Schemer, are also supported by SchemaOf.
+type Schemer interface {
+	Schema() Validator
+}
+
+// SchemaOf returns the validation schema defined by the Schema method of
+// value, which returns a Schema, an OrderedSchema or any other Validator.
+// It reports false if value has no such method.
+func SchemaOf(value any) (Validator, bool) {
+	switch s := value.(type) {
+	case interface{ Schema() Schema }:
+		return s.Schema(), true
+	case interface{ Schema() OrderedSchema }:
+		return s.Schema(), true
+	case Schemer:
+		return s.Schema(), true
+	default:
+		return nil, false
+	}
+}
+
+// ContextValidator is an interface for representing a validator, which
+// honors the deadline and cancellation of the given context.
+//
+// All composite validators will call ValidateContext, instead of Validate,
+// on their sub-validators that implement ContextValidator.
+type ContextValidator interface {
+	Validator
+	ValidateContext(ctx context.Context, field *Field) Errors
+}
+
+// Validate invokes v.Validate with an empty field, which carries the
+// given options throughout the validation run.
+func Validate(v Validator, opts ...Option) (errs Errors) {
+	return ValidateContext(context.Background(), v, opts...)
+}
+
+// ValidateContext is like Validate, but the validation run will carry
+// the given context, which can be accessed by Field.Context.
+//
+// Once ctx is done, the rest of the validation will be skipped and
+// an error of kind ErrCanceled will be reported. A nil ctx is treated
+// as context.Background().
+func ValidateContext(ctx context.Context, v Validator, opts ...Option) (errs Errors) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	r := newRun(ctx, opts)
+	errs = validate(v, &Field{run: r})
+	return r.finish(errs)
+}
+
+// Check is like Validate, but keeps the programming mistakes apart from the
+// validation failures. It returns nil if the validation succeeds, or an
+// *UnsupportedError holding all the errors of kind ErrUnsupported if there
+// is any, or Errors otherwise.
+func Check(v Validator, opts ...Option) error {
+	return CheckContext(context.Background(), v, opts...)
+}
+
+// CheckContext is like Check, but the validation run will carry the given
+// context. See ValidateContext.
+func CheckContext(ctx context.Context, v Validator, opts ...Option) error {
+	errs := ValidateContext(ctx, v, opts...)
+	if unsupported := errs.filter(ErrUnsupported); unsupported != nil {
+		return &UnsupportedError{Errors: unsupported}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate calls v.ValidateContext if v is a ContextValidator, or calls
+// v.Validate otherwise.
+func validate(v Validator, field *Field) Errors {
+	if cv, ok := v.(ContextValidator); ok {
+		return cv.ValidateContext(field.Context(), field)
+	}
+	return v.Validate(field)
 }
@@ -0,0 +1,382 @@
+package validating_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+func TestValidate_Options(t *testing.T) {
+	type Item struct {
+		Name  string
+		Count int
+	}
+
+	calls := 0
+	counted := v.Func(func(field *v.Field) v.Errors {
+		calls++
+		return v.NewInvalidErrors(field, "is invalid")
+	})
+	schema := v.OrderedSchema{}.
+		Add(v.F("name", ""), v.Nonzero[string]()).
+		Add(v.F("items", []Item{{}, {}}), v.EachSlice[[]Item](v.Nested(func(i Item) v.Validator {
+			return v.OrderedSchema{}.
+				Add(v.F("name", i.Name), v.Nonzero[string]()).
+				Add(v.F("count", i.Count), v.Nonzero[int]())
+		}))).
+		Add(v.F("other", 0), counted)
+
+	cases := []struct {
+		name  string
+		opts  []v.Option
+		errs  v.Errors
+		calls int
+	}{
+		{
+			name: "no options",
+			errs: v.Errors{
+				v.NewError("name", v.ErrInvalid, "is zero valued"),
+				v.NewError("items[0].name", v.ErrInvalid, "is zero valued"),
+				v.NewError("items[0].count", v.ErrInvalid, "is zero valued"),
+				v.NewError("items[1].name", v.ErrInvalid, "is zero valued"),
+				v.NewError("items[1].count", v.ErrInvalid, "is zero valued"),
+				v.NewError("other", v.ErrInvalid, "is invalid"),
+			},
+			calls: 1,
+		},
+		{
+			name: "fail fast",
+			opts: []v.Option{v.WithFailFast()},
+			errs: v.Errors{
+				v.NewError("name", v.ErrInvalid, "is zero valued"),
+			},
+			calls: 0,
+		},
+		{
+			name: "max errors",
+			opts: []v.Option{v.WithMaxErrors(3)},
+			errs: v.Errors{
+				v.NewError("name", v.ErrInvalid, "is zero valued"),
+				v.NewError("items[0].name", v.ErrInvalid, "is zero valued"),
+				v.NewError("items[0].count", v.ErrInvalid, "is zero valued"),
+			},
+			calls: 0,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			calls = 0
+			errs := v.Validate(schema, c.opts...)
+			if !reflect.DeepEqual(plainErrs(errs), c.errs) {
+				t.Errorf("Got (%+v) != Want (%+v)", errs, c.errs)
+			}
+			if calls != c.calls {
+				t.Errorf("Got calls (%d) != Want calls (%d)", calls, c.calls)
+			}
+		})
+	}
+}
+
+func TestValidate_FailFastAny(t *testing.T) {
+	schema := v.OrderedSchema{}.
+		Add(v.F("value", "abc"), v.Any(
+			v.Value("abc", v.LenString(1, 2)),
+			v.Value("abc", v.In("abc")),
+		)).
+		Add(v.F("other", ""), v.Nonzero[string]())
+
+	errs := v.Validate(schema, v.WithFailFast())
+	want := v.Errors{
+		v.NewError("other", v.ErrInvalid, "is zero valued"),
+	}
+	if !reflect.DeepEqual(plainErrs(errs), want) {
+		t.Errorf("Got (%+v) != Want (%+v)", errs, want)
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	type ctxKey struct{}
+
+	dbCheck := v.FuncContext(func(ctx context.Context, field *v.Field) v.Errors {
+		if ctx.Value(ctxKey{}) != field.Value {
+			return v.NewInvalidErrors(field, "does not exist")
+		}
+		return nil
+	})
+	schema := v.OrderedSchema{}.
+		Add(v.F("id", "1"), dbCheck).
+		Add(v.F("ids", []string{"1", "2"}), v.EachSlice[[]string](dbCheck)).
+		Add(v.F("nested", "2"), v.Nested(func(s string) v.Validator {
+			return v.All(v.Nonzero[string](), dbCheck)
+		}))
+
+	t.Run("value", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ctxKey{}, "1")
+		errs := v.ValidateContext(ctx, schema)
+		want := v.Errors{
+			v.NewError("ids[1]", v.ErrInvalid, "does not exist"),
+			v.NewError("nested", v.ErrInvalid, "does not exist"),
+		}
+		if !reflect.DeepEqual(plainErrs(errs), want) {
+			t.Errorf("Got (%+v) != Want (%+v)", errs, want)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		schema := v.OrderedSchema{}.
+			Add(v.F("a", 0), v.Func(func(field *v.Field) v.Errors {
+				calls++
+				cancel()
+				return nil
+			})).
+			Add(v.F("b", 0), v.Func(func(field *v.Field) v.Errors {
+				calls++
+				return nil
+			}))
+
+		errs := v.ValidateContext(ctx, schema)
+		want := v.Errors{
+			v.NewError("", v.ErrCanceled, context.Canceled.Error()),
+		}
+		if !reflect.DeepEqual(plainErrs(errs), want) {
+			t.Errorf("Got (%+v) != Want (%+v)", errs, want)
+		}
+		if calls != 1 {
+			t.Errorf("Got calls (%d) != Want calls (%d)", calls, 1)
+		}
+	})
+
+	t.Run("nil context", func(t *testing.T) {
+		var ctx context.Context
+		errs := v.ValidateContext(ctx, v.Value("", v.Nonzero[string]()))
+		want := v.NewErrors("", v.ErrInvalid, "is zero valued")
+		if !reflect.DeepEqual(plainErrs(errs), want) {
+			t.Errorf("Got (%+v) != Want (%+v)", errs, want)
+		}
+	})
+}
+
+func TestValidate_PanicOnUnsupported(t *testing.T) {
+	schema := v.Schema{
+		v.F("name", ""):      v.Nonzero[string](),
+		v.F("age", int64(1)): v.Any(v.Zero[int64](), v.Gte(10)),
+	}
+
+	// No panic without the option.
+	if errs := v.Validate(schema); len(errs) != 3 {
+		t.Fatalf("Got (%v) != Want (3 errors)", errs)
+	}
+
+	defer func() {
+		got, ok := recover().(*v.UnsupportedError)
+		if !ok {
+			t.Fatalf("Got (%v) != Want (*UnsupportedError)", got)
+		}
+		want := v.NewErrors("age", v.ErrUnsupported, "Gte expected int but got int64")
+		if !reflect.DeepEqual(got.Errors, want) {
+			t.Errorf("Got (%+v) != Want (%+v)", got.Errors, want)
+		}
+	}()
+	v.Validate(schema, v.WithPanicOnUnsupported())
+	t.Error("Want panic")
+}
+
+func TestValidate_PanicOnUnsupported_Discarded(t *testing.T) {
+	// The UNSUPPORTED error from LenString is discarded by Any, since Gte
+	// succeeds.
+	schema := v.Value(1.5, v.Any(v.LenString(1, 5), v.Gte(1.0)))
+
+	if errs := v.Validate(schema, v.WithPanicOnUnsupported()); errs != nil {
+		t.Errorf("Got (%+v) != Want (nil)", errs)
+	}
+	if err := v.Check(schema); err != nil {
+		t.Errorf("Got (%+v) != Want (nil)", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cases := []struct {
+		name      string
+		validator v.Validator
+		want      error
+	}{
+		{
+			name:      "valid",
+			validator: v.Value("a", v.Nonzero[string]()),
+			want:      nil,
+		},
+		{
+			name:      "invalid",
+			validator: v.Value("", v.Nonzero[string]()),
+			want:      v.NewErrors("", v.ErrInvalid, "is zero valued"),
+		},
+		{
+			name:      "unsupported but discarded",
+			validator: v.Value(1.5, v.Any(v.LenString(1, 5), v.Gte(1.0))),
+			want:      nil,
+		},
+		{
+			name: "unsupported",
+			validator: v.Schema{
+				v.F("name", ""):      v.Nonzero[string](),
+				v.F("age", int64(1)): v.Gte(10),
+			},
+			want: &v.UnsupportedError{
+				Errors: v.NewErrors("age", v.ErrUnsupported, "Gte expected int but got int64"),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := v.Check(c.validator)
+			if errs, ok := got.(v.Errors); ok {
+				got = plainErrs(errs)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%#v) != Want (%#v)", got, c.want)
+			}
+		})
+	}
+
+	err := v.Check(v.Value(1, v.Nonzero[string]()))
+	if want := "validating: unsupported: UNSUPPORTED(Nonzero expected string but got int)"; err.Error() != want {
+		t.Errorf("Got (%s) != Want (%s)", err, want)
+	}
+}
+
+func TestValidate_WithFields(t *testing.T) {
+	type Address struct {
+		Country string
+		City    string
+	}
+	type Person struct {
+		Name    string
+		Tags    []string
+		Address Address
+		Version int
+	}
+	address := func(a Address) v.Validator {
+		return v.Schema{
+			v.F("country", a.Country): v.Nonzero[string](),
+			v.F("city", a.City):       v.Nonzero[string](),
+		}
+	}
+	schema := func(p Person) v.Schema {
+		return v.Schema{
+			v.F("name", p.Name):       v.Nonzero[string](),
+			v.F("tags", p.Tags):       v.EachSlice[[]string](v.Nonzero[string]()),
+			v.F("address", p.Address): v.Nested(address),
+			v.F("version", p.Version): v.Always(v.Gt(0)),
+		}
+	}
+	p := Person{Tags: []string{""}}
+
+	cases := []struct {
+		name string
+		opts []v.Option
+		want []string
+	}{
+		{
+			name: "all fields",
+			opts: nil,
+			want: []string{"address.city", "address.country", "name", "tags[0]", "version"},
+		},
+		{
+			name: "no fields",
+			opts: []v.Option{v.WithFields()},
+			want: []string{"version"},
+		},
+		{
+			name: "selected fields",
+			opts: []v.Option{v.WithFields("name", "tags")},
+			want: []string{"name", "tags[0]", "version"},
+		},
+		{
+			name: "nested fields",
+			opts: []v.Option{v.WithFields("address.city")},
+			want: []string{"address.city", "version"},
+		},
+		{
+			name: "ancestor fields",
+			opts: []v.Option{v.WithFields("address")},
+			want: []string{"address.city", "address.country", "version"},
+		},
+		{
+			name: "similar names",
+			opts: []v.Option{v.WithFields("nam", "address.cit")},
+			want: []string{"version"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(schema(p), c.opts...)
+			var got []string
+			for name := range errs.Map() {
+				got = append(got, name)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", got, c.want)
+			}
+		})
+	}
+
+	t.Run("always nested", func(t *testing.T) {
+		s := v.Schema{
+			v.F("name", ""):           v.Nonzero[string](),
+			v.F("address", Address{}): v.Always(v.Nested(address)),
+		}
+		errs := v.Validate(s, v.WithFields("name"))
+		if len(errs) != 3 {
+			t.Errorf("Got (%+v) != Want 3 errors", errs)
+		}
+	})
+}
+
+type schemaPerson struct{ Name string }
+
+func (p schemaPerson) Schema() v.Schema {
+	return v.Schema{v.F("name", p.Name): v.Nonzero[string]()}
+}
+
+type orderedPerson struct{ Name string }
+
+func (p orderedPerson) Schema() v.OrderedSchema {
+	return v.OrderedSchema{}.Add(v.F("name", p.Name), v.Nonzero[string]())
+}
+
+type validatorPerson struct{ Name string }
+
+func (p validatorPerson) Schema() v.Validator {
+	return v.Value(p.Name, v.Nonzero[string]())
+}
+
+func TestSchemaOf(t *testing.T) {
+	cases := []struct {
+		name  string
+		value any
+		ok    bool
+	}{
+		{name: "schema", value: schemaPerson{}, ok: true},
+		{name: "ordered schema", value: orderedPerson{}, ok: true},
+		{name: "validator", value: validatorPerson{}, ok: true},
+		{name: "none", value: 0, ok: false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schema, ok := v.SchemaOf(c.value)
+			if ok != c.ok {
+				t.Fatalf("Got ok (%v) != Want ok (%v)", ok, c.ok)
+			}
+			if ok && len(v.Validate(schema)) != 1 {
+				t.Errorf("Got (%v) != Want 1 error", v.Validate(schema))
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Command validatingcheck runs the validatingcheck analyzer.
+//
+// Usage:
+//
+//	validatingcheck [flags] [packages]
+//
+// Or, along with the other checks of go vet:
+//
+//	go vet -vettool=$(which validatingcheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/RussellLuo/validating/v3/validatingcheck"
+)
+
+func main() {
+	singlechecker.Main(validatingcheck.Analyzer)
+}
@@ -0,0 +1,108 @@
+package a
+
+import (
+	"database/sql"
+	"time"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+type Name string
+
+type Address struct {
+	City string
+}
+
+func (a Address) Schema() v.Schema {
+	return v.Schema{
+		v.F("city", a.City): v.LenString(1, 10),
+	}
+}
+
+type Person struct {
+	Name    Name
+	Age     int64
+	Score   float64
+	Tags    []string
+	Labels  map[string]int
+	Address *Address
+	Extra   any
+}
+
+func (p Person) Schema() v.Schema {
+	return v.Schema{
+		v.F("name", p.Name):  v.LenString(1, 10), // want `LenString expects string but the field's value is of type Name, which always results in UNSUPPORTED`
+		v.F("name2", p.Name): v.In[Name]("a", "b"),
+		v.F("age", p.Age):    v.Gte(10), // want `Gte expects int but the field's value is of type int64, which always results in UNSUPPORTED`
+		v.F("age2", p.Age):   v.Gte[int64](10),
+		v.F("score", p.Score): v.All(
+			v.Nonzero[float64](),
+			v.Range(0, 100).Msg("bad"), // want `Range expects int but the field's value is of type float64, which always results in UNSUPPORTED`
+		),
+		v.F("tags", p.Tags):     v.EachSlice[[]string](v.Match(`^a`)),
+		v.F("tags2", p.Tags):    v.EachSlice[[]string](v.Gte(1)), // want `Gte expects int but the field's value is of type string, which always results in UNSUPPORTED`
+		v.F("labels", p.Labels): v.EachMap[map[string]int](v.Any(v.Zero[int](), v.Gte(1)).LastError()),
+		v.F("labels2", p.Labels): v.Nested(func(m map[string]int64) v.Validator { // want `Nested expects map\[string\]int64 but the field's value is of type map\[string\]int, which always results in UNSUPPORTED`
+			return nil
+		}),
+		v.F("address", p.Address):  v.Nested(func(a *Address) v.Validator { return a.Schema() }),
+		v.F("address2", p.Address): v.Nonzero[Address](), // want `Nonzero expects Address but the field's value is of type \*Address, which always results in UNSUPPORTED`
+		v.F("extra", p.Extra):      v.Gte(1),
+		v.F("const", 10):           v.Eq(10),
+		v.F("is", p.Age):           v.Is(func(i any) bool { return true }),
+		v.F("zeroor", p.Age):       v.ZeroOr[int64](v.Gte(1)), // want `Gte expects int but the field's value is of type int64, which always results in UNSUPPORTED`
+		v.F("when", p.Age): v.When(true, v.Gte[int64](1)).
+			Else(v.Gte(2)). // want `Gte expects int but the field's value is of type int64, which always results in UNSUPPORTED`
+			Cond("always"),
+	}
+}
+
+func Ordered(p Person) v.Validator {
+	return v.OrderedSchema{}.
+		Add(v.F("name", p.Name), v.RuneCount(1, 2)). // want `RuneCount expects string or \[\]byte but the field's value is of type Name, which always results in UNSUPPORTED`
+		Add(v.F("age", p.Age), v.Not(v.In(1, 2))).   // want `In expects int but the field's value is of type int64, which always results in UNSUPPORTED`
+		Add(v.F("age", p.Age), v.Always(v.Gte(1)))   // want `Gte expects int but the field's value is of type int64, which always results in UNSUPPORTED`
+}
+
+func Value(age int32) v.Validator {
+	return v.Value(age, v.Gte(1)) // want `Gte expects int but the field's value is of type int32, which always results in UNSUPPORTED`
+}
+
+type Period struct {
+	Start  time.Time
+	End    time.Time
+	Days   int64
+	MaxDay int
+}
+
+func (p Period) Schema() v.Schema {
+	start := v.F("start", p.Start)
+	return v.Schema{
+		start:                v.Before(time.Now()),
+		v.F("end", p.End):    v.AfterField(start),
+		v.F("days", p.Days):  v.AfterField(start),     // want `AfterField expects time.Time but the field's value is of type int64, which always results in UNSUPPORTED`
+		v.F("max", p.MaxDay): v.EqField[int64](start), // want `EqField expects int64 but the field's value is of type int, which always results in UNSUPPORTED`
+	}
+}
+
+type Patch struct {
+	Name   v.Opt[string]
+	Age    *int64
+	Email  sql.NullString
+	Active bool
+	Score  sql.NullFloat64
+}
+
+func (p Patch) Schema() v.Schema {
+	return v.Schema{
+		v.F("name", p.Name):     v.Required[string](v.LenString(1, 10)),
+		v.F("name2", p.Name):    v.Required[int](), // want `Required expects int or \*int or validating.Opt\[int\] but the field's value is of type validating.Opt\[string\], which always results in UNSUPPORTED`
+		v.F("age", p.Age):       v.Optional[int64](v.Gte[int64](0)),
+		v.F("age2", p.Age):      v.Optional[int64](v.Gte(0)), // want `Gte expects int but the field's value is of type int64, which always results in UNSUPPORTED`
+		v.F("age3", p.Age):      v.Required[int](),           // want `Required expects int or \*int or validating.Opt\[int\] but the field's value is of type \*int64, which always results in UNSUPPORTED`
+		v.F("email", p.Email):   v.Optional[string](v.Match(`@`)),
+		v.F("active", p.Active): v.Required[bool](),
+		v.F("score", p.Score):   v.Optional[float64](),
+		v.F("score2", p.Score):  v.Optional[int64](), // want `Optional expects int64 or \*int64 or validating.Opt\[int64\] but the field's value is of type sql.NullFloat64, which always results in UNSUPPORTED`
+	}
+}
@@ -0,0 +1,99 @@
+// Package validating is a stub of the validating package for tests.
+package validating
+
+import "time"
+
+type Field struct {
+	Name  string
+	Value any
+}
+
+func F(name string, value any) *Field { return &Field{Name: name, Value: value} }
+
+type Errors []error
+
+type Validator interface {
+	Validate(field *Field) Errors
+}
+
+type Func func(field *Field) Errors
+
+func (f Func) Validate(field *Field) Errors { return f(field) }
+
+type Schema map[*Field]Validator
+
+func (s Schema) Validate(field *Field) Errors { return nil }
+
+type FieldValidator struct {
+	Field     *Field
+	Validator Validator
+}
+
+type OrderedSchema []FieldValidator
+
+func (s OrderedSchema) Add(field *Field, validator Validator) OrderedSchema { return s }
+
+func (s OrderedSchema) Validate(field *Field) Errors { return nil }
+
+func Value(value any, validator Validator) Schema { return nil }
+
+type MessageValidator struct{ Validator Validator }
+
+func (mv *MessageValidator) Msg(msg string) *MessageValidator { return mv }
+
+func (mv *MessageValidator) Validate(field *Field) Errors { return nil }
+
+type AnyValidator struct{}
+
+func (av *AnyValidator) LastError() *AnyValidator { return av }
+
+func (av *AnyValidator) Validate(field *Field) Errors { return nil }
+
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64 | ~string
+}
+
+func Nested[T any](f func(T) Validator) Validator                           { return nil }
+func EachMap[T map[K]V, K comparable, V any](validator Validator) Validator { return nil }
+func EachSlice[T ~[]E, E any](validator Validator) Validator                { return nil }
+func All(validators ...Validator) Validator                                 { return nil }
+func Any(validators ...Validator) *AnyValidator                             { return nil }
+func Not(validator Validator) *MessageValidator                             { return nil }
+func Always(validator Validator) Validator                                  { return nil }
+func Is[T any](f func(T) bool) *MessageValidator                            { return nil }
+func Nonzero[T comparable]() *MessageValidator                              { return nil }
+func Zero[T comparable]() *MessageValidator                                 { return nil }
+func ZeroOr[T comparable](validator Validator) Validator                    { return nil }
+func LenString(min, max int) *MessageValidator                              { return nil }
+func LenSlice[T ~[]E, E any](min, max int) *MessageValidator                { return nil }
+func RuneCount(min, max int) *MessageValidator                              { return nil }
+func Eq[T comparable](value T) *MessageValidator                            { return nil }
+func Gte[T ordered](value T) *MessageValidator                              { return nil }
+func Range[T ordered](min, max T) *MessageValidator                         { return nil }
+func In[T comparable](values ...T) *MessageValidator                        { return nil }
+func Match(pattern any) *MessageValidator                                   { return nil }
+
+type ConditionalValidator struct{}
+
+func (cv *ConditionalValidator) Else(validator Validator) *ConditionalValidator { return cv }
+
+func (cv *ConditionalValidator) Cond(description string) *ConditionalValidator { return cv }
+
+func (cv *ConditionalValidator) Validate(field *Field) Errors { return nil }
+
+func When(cond bool, then Validator) *ConditionalValidator { return nil }
+
+func EqField[T comparable](other *Field) *MessageValidator { return nil }
+
+func AfterField(other *Field) *MessageValidator { return nil }
+
+func Before(t time.Time) *MessageValidator { return nil }
+
+type Opt[T any] struct {
+	Value T
+	Set   bool
+}
+
+func Required[T any](validators ...Validator) *MessageValidator { return nil }
+
+func Optional[T any](validators ...Validator) Validator { return nil }
@@ -0,0 +1,333 @@
+// Package validatingcheck defines an analyzer, which reports validators that
+// can never accept the values of the fields they are associated with.
+//
+// Most built-in validators are generic, and they assert the field's value to
+// their type parameters at runtime. Thus the following code compiles, but
+// always fails with UNSUPPORTED(Gte expected int but got int64):
+//
+//	var age int64
+//	v.Schema{
+//		v.F("age", age): v.Gte(10),
+//	}
+//
+// The analyzer inspects the field-validator pairs in Schema literals,
+// OrderedSchema.Add and Value calls, and reports when the validator (or any
+// validator composed by All, Any, Not, When, ZeroOr, Required, Optional,
+// EachSlice or EachMap) cannot accept the static type of the field's value.
+package validatingcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const pkgPath = "github.com/RussellLuo/validating/v3"
+
+// Analyzer reports validators mismatching the types of field values.
+var Analyzer = &analysis.Analyzer{
+	Name:     "validatingcheck",
+	Doc:      "report validators which cannot accept the types of field values",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c := &checker{pass: pass}
+
+	nodeFilter := []ast.Node{(*ast.CompositeLit)(nil), (*ast.CallExpr)(nil)}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if !isNamed(pass.TypesInfo.TypeOf(n), "Schema") {
+				return
+			}
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if value, ok := c.fieldValue(kv.Key); ok {
+					c.check(kv.Value, value)
+				}
+			}
+		case *ast.CallExpr:
+			switch {
+			case c.isFunc(n.Fun, "Value") && len(n.Args) == 2:
+				c.check(n.Args[1], c.typeOf(n.Args[0]))
+			case c.isMethod(n.Fun, "OrderedSchema", "Add") && len(n.Args) == 2:
+				if value, ok := c.fieldValue(n.Args[0]); ok {
+					c.check(n.Args[1], value)
+				}
+			}
+		}
+	})
+	return nil, nil
+}
+
+type checker struct {
+	pass *analysis.Pass
+}
+
+// fieldValue returns the static type of the value in the field expression,
+// which must be a call to F.
+func (c *checker) fieldValue(expr ast.Expr) (types.Type, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !c.isFunc(call.Fun, "F") || len(call.Args) != 2 {
+		return nil, false
+	}
+	return c.typeOf(call.Args[1]), true
+}
+
+func (c *checker) typeOf(expr ast.Expr) types.Type {
+	t := c.pass.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return nil
+	}
+	return types.Default(t)
+}
+
+// check reports if the validator cannot accept values of type value.
+func (c *checker) check(validator ast.Expr, value types.Type) {
+	if value == nil || types.IsInterface(value) {
+		return // The dynamic type is unknown.
+	}
+
+	call, ok := ast.Unparen(validator).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	// Chained methods (e.g. Msg and LastError) return the receiver.
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		switch {
+		case c.isMethod(call.Fun, "MessageValidator", "Msg"),
+			c.isMethod(call.Fun, "AnyValidator", "LastError"),
+			c.isMethod(call.Fun, "ConditionalValidator", "Cond"):
+			c.check(sel.X, value)
+			return
+		case c.isMethod(call.Fun, "ConditionalValidator", "Else"):
+			c.check(sel.X, value)
+			if len(call.Args) == 1 {
+				c.check(call.Args[0], value)
+			}
+			return
+		}
+	}
+
+	name, typeArgs, ok := c.builtin(call.Fun)
+	if !ok {
+		return
+	}
+
+	switch name {
+	case "All", "And", "Any", "Or":
+		for _, arg := range call.Args {
+			c.check(arg, value)
+		}
+		return
+	case "Not", "Always":
+		if len(call.Args) == 1 {
+			c.check(call.Args[0], value)
+		}
+		return
+	case "When", "If", "Unless":
+		if len(call.Args) == 2 {
+			c.check(call.Args[1], value)
+		}
+		return
+	case "LenString":
+		c.want(call, name, value, types.Typ[types.String])
+		return
+	case "Before", "After", "BeforeField", "AfterField":
+		if !isTime(value) {
+			c.report(call, name, "time.Time", value)
+		}
+		return
+	case "RuneCount", "Match":
+		c.want(call, name, value, types.Typ[types.String], types.NewSlice(types.Universe.Lookup("byte").Type()))
+		return
+	}
+
+	if typeArgs == nil || typeArgs.Len() == 0 {
+		return
+	}
+	want := typeArgs.At(0)
+
+	switch name {
+	case "Nonzero", "Zero", "Eq", "Ne", "Gt", "Gte", "Lt", "Lte", "Range",
+		"In", "Nin", "Is", "Nested", "Map", "Slice", "Array", "LenSlice",
+		"EqField", "NeField", "GtField", "GteField", "LtField", "LteField":
+		c.want(call, name, value, want)
+	case "ZeroOr":
+		if c.want(call, name, value, want) && len(call.Args) == 1 {
+			c.check(call.Args[0], value)
+		}
+	case "EachSlice", "EachMap":
+		if c.want(call, name, value, want) && len(call.Args) == 1 {
+			c.check(call.Args[0], elem(want))
+		}
+	case "Required", "Optional":
+		if !accepts(value, want) && !accepts(presence(value), want) {
+			t := types.TypeString(want, c.qualifier)
+			c.report(call, name, t+" or *"+t+" or validating.Opt["+t+"]", value)
+			return
+		}
+		for _, arg := range call.Args {
+			c.check(arg, want)
+		}
+	}
+}
+
+// presence returns the type of the values held by t, which is the element
+// type if t is a pointer, v.Opt or one of the sql.Null* types, or t itself
+// otherwise.
+func presence(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return t
+	}
+	if isNamed(named, "Opt") && named.TypeArgs().Len() == 1 {
+		return named.TypeArgs().At(0)
+	}
+
+	obj := named.Obj()
+	if obj.Pkg() != nil && obj.Pkg().Path() == "database/sql" && strings.HasPrefix(obj.Name(), "Null") {
+		// E.g. sql.NullString{String string; Valid bool}.
+		if st, ok := named.Underlying().(*types.Struct); ok && st.NumFields() == 2 && st.Field(1).Name() == "Valid" {
+			return st.Field(0).Type()
+		}
+	}
+	return t
+}
+
+// want reports if value is not any of the wanted types, as the validator
+// asserts the field's value to them.
+func (c *checker) want(call *ast.CallExpr, name string, value types.Type, wants ...types.Type) bool {
+	for _, want := range wants {
+		if accepts(value, want) {
+			return true
+		}
+	}
+
+	expected := types.TypeString(wants[0], c.qualifier)
+	for _, want := range wants[1:] {
+		expected += " or " + types.TypeString(want, c.qualifier)
+	}
+	c.report(call, name, expected, value)
+	return false
+}
+
+// accepts reports whether a value of type value can be asserted to want.
+func accepts(value, want types.Type) bool {
+	if types.Identical(value, want) {
+		return true
+	}
+	iface, ok := want.Underlying().(*types.Interface)
+	return ok && types.Implements(value, iface)
+}
+
+func (c *checker) report(call *ast.CallExpr, name, expected string, value types.Type) {
+	c.pass.Reportf(call.Pos(), "%s expects %s but the field's value is of type %s, which always results in UNSUPPORTED",
+		name, expected, types.TypeString(value, c.qualifier))
+}
+
+func (c *checker) qualifier(pkg *types.Package) string {
+	if pkg == c.pass.Pkg {
+		return ""
+	}
+	return pkg.Name()
+}
+
+// builtin returns the name and the type arguments of the built-in
+// validator factory called by fun.
+func (c *checker) builtin(fun ast.Expr) (string, *types.TypeList, bool) {
+	var ident *ast.Ident
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return "", nil, false
+	}
+
+	obj, ok := c.pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || obj.Pkg() == nil || obj.Pkg().Path() != pkgPath {
+		return "", nil, false
+	}
+	if sig, ok := obj.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return "", nil, false
+	}
+	return obj.Name(), c.pass.TypesInfo.Instances[ident].TypeArgs, true
+}
+
+func (c *checker) isFunc(fun ast.Expr, name string) bool {
+	n, _, ok := c.builtin(fun)
+	return ok && n == name
+}
+
+// isMethod reports whether fun is the method of the given type declared in
+// the validating package.
+func (c *checker) isMethod(fun ast.Expr, typeName, method string) bool {
+	sel, ok := ast.Unparen(fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != method {
+		return false
+	}
+	s, ok := c.pass.TypesInfo.Selections[sel]
+	if !ok {
+		return false
+	}
+	recv := s.Recv()
+	if p, ok := recv.(*types.Pointer); ok {
+		recv = p.Elem()
+	}
+	return isNamed(recv, typeName)
+}
+
+// isNamed reports whether t is the named type declared in the validating
+// package.
+func isNamed(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// isTime reports whether t is time.Time.
+func isTime(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time"
+}
+
+// elem returns the element type of the slice or map type t.
+func elem(t types.Type) types.Type {
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem()
+	case *types.Map:
+		return u.Elem()
+	default:
+		return nil
+	}
+}
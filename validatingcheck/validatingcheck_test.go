@@ -0,0 +1,13 @@
+package validatingcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/RussellLuo/validating/v3/validatingcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), validatingcheck.Analyzer, "a")
+}
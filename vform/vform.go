@@ -0,0 +1,187 @@
+// Package vform parses and validates url.Values, such as query strings
+// and form posts, by declaring the parsing and the validation of each key
+// together.
+//
+// For example:
+//
+//	var page int
+//	var tags []string
+//	if err := r.ParseForm(); err != nil {
+//		...
+//	}
+//	errs := v.Validate(vform.Values(r.Form,
+//		vform.Int("page", &page, v.Gte(1)),
+//		vform.Strings("tag", &tags, v.LenSlice[[]string](0, 5)),
+//	))
+//
+// Both the parsing failures and the validation failures are reported as
+// v.Errors, whose field names are the keys.
+package vform
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+// Error codes of parsing errors.
+const (
+	CodeType   = "type"   // The value can not be parsed as the expected type.
+	CodeFormat = "format" // The value can not be parsed by a custom parser.
+	CodeLayout = "layout" // The value can not be parsed as a time per the layout.
+)
+
+// Param declares how to parse and validate the value(s) of a key.
+type Param interface {
+	// add parses the value(s) from values, stores the result, and then adds
+	// the corresponding fields, along with their validators, to s.
+	add(s v.OrderedSchema, values url.Values) v.OrderedSchema
+}
+
+// Values creates a validator, which will parse and validate the given
+// values per params, in the declaration order of params.
+func Values(values url.Values, params ...Param) v.Validator {
+	return v.Func(func(field *v.Field) v.Errors {
+		var s v.OrderedSchema
+		for _, p := range params {
+			s = p.add(s, values)
+		}
+		return s.Validate(field)
+	})
+}
+
+type param[T any] struct {
+	key       string
+	dst       *T
+	parse     func(string) (T, error)
+	fail      v.Validator // The validator reporting parsing failures.
+	validator v.Validator
+}
+
+// Value declares a key with a single value, which will be parsed by parse
+// and stored in dst, and then be validated by validator (if not nil).
+//
+// If the key is absent, or its value is empty, dst is left unchanged and
+// validator will validate the unchanged value. Thus defaults can be set
+// by initializing dst beforehand.
+func Value[T any](key string, dst *T, parse func(string) (T, error), validator v.Validator) Param {
+	return param[T]{key: key, dst: dst, parse: parse, fail: v.Fail(CodeFormat, nil), validator: validator}
+}
+
+func (p param[T]) add(s v.OrderedSchema, values url.Values) v.OrderedSchema {
+	if raw := values.Get(p.key); raw != "" {
+		value, err := p.parse(raw)
+		if err != nil {
+			return s.Add(v.F(p.key, raw), p.fail)
+		}
+		*p.dst = value
+	}
+
+	if p.validator == nil {
+		return s
+	}
+	return s.Add(v.F(p.key, *p.dst), p.validator)
+}
+
+type sliceParam[T any] struct {
+	key       string
+	dst       *[]T
+	parse     func(string) (T, error)
+	fail      v.Validator // The validator reporting parsing failures.
+	validator v.Validator
+}
+
+// Slice declares a key with repeated values, each of which will be parsed
+// by parse, and all of them will be stored in dst and then be validated by
+// validator (if not nil).
+//
+// If the key is absent, dst is left unchanged and validator will validate
+// the unchanged value. Parsing failures are reported per value, with field
+// names like "key[0]".
+func Slice[T any](key string, dst *[]T, parse func(string) (T, error), validator v.Validator) Param {
+	return sliceParam[T]{key: key, dst: dst, parse: parse, fail: v.Fail(CodeFormat, nil), validator: validator}
+}
+
+func (p sliceParam[T]) add(s v.OrderedSchema, values url.Values) v.OrderedSchema {
+	if raws, ok := values[p.key]; ok {
+		result := make([]T, len(raws))
+		failed := false
+		for i, raw := range raws {
+			value, err := p.parse(raw)
+			if err != nil {
+				s = s.Add(v.F(p.key+"["+strconv.Itoa(i)+"]", raw), p.fail)
+				failed = true
+				continue
+			}
+			result[i] = value
+		}
+		if failed {
+			return s
+		}
+		*p.dst = result
+	}
+
+	if p.validator == nil {
+		return s
+	}
+	return s.Add(v.F(p.key, *p.dst), p.validator)
+}
+
+// typeError creates a validator, which always fails with a parsing error
+// of the expected type (named as in JSON).
+func typeError(expected string) v.Validator {
+	return v.Fail(CodeType, map[string]any{"expected": expected})
+}
+
+func parseString(s string) (string, error) { return s, nil }
+
+func parseInt(s string) (int, error) { return strconv.Atoi(s) }
+
+func parseInt64(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
+
+func parseFloat64(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
+
+// String declares a key with a single string value.
+func String(key string, dst *string, validator v.Validator) Param {
+	return param[string]{key: key, dst: dst, parse: parseString, fail: typeError("string"), validator: validator}
+}
+
+// Int declares a key with a single int value.
+func Int(key string, dst *int, validator v.Validator) Param {
+	return param[int]{key: key, dst: dst, parse: parseInt, fail: typeError("integer"), validator: validator}
+}
+
+// Int64 declares a key with a single int64 value.
+func Int64(key string, dst *int64, validator v.Validator) Param {
+	return param[int64]{key: key, dst: dst, parse: parseInt64, fail: typeError("integer"), validator: validator}
+}
+
+// Float64 declares a key with a single float64 value.
+func Float64(key string, dst *float64, validator v.Validator) Param {
+	return param[float64]{key: key, dst: dst, parse: parseFloat64, fail: typeError("number"), validator: validator}
+}
+
+// Bool declares a key with a single bool value, which is parsed by
+// strconv.ParseBool.
+func Bool(key string, dst *bool, validator v.Validator) Param {
+	return param[bool]{key: key, dst: dst, parse: strconv.ParseBool, fail: typeError("boolean"), validator: validator}
+}
+
+// Time declares a key with a single time.Time value, which is parsed per
+// the given layout.
+func Time(key, layout string, dst *time.Time, validator v.Validator) Param {
+	parse := func(s string) (time.Time, error) { return time.Parse(layout, s) }
+	return param[time.Time]{key: key, dst: dst, parse: parse, fail: v.Fail(CodeLayout, map[string]any{"layout": layout}), validator: validator}
+}
+
+// Strings declares a key with repeated string values.
+func Strings(key string, dst *[]string, validator v.Validator) Param {
+	return sliceParam[string]{key: key, dst: dst, parse: parseString, fail: typeError("string"), validator: validator}
+}
+
+// Ints declares a key with repeated int values.
+func Ints(key string, dst *[]int, validator v.Validator) Param {
+	return sliceParam[int]{key: key, dst: dst, parse: parseInt, fail: typeError("integer"), validator: validator}
+}
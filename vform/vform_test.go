@@ -0,0 +1,149 @@
+package vform_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/vform"
+)
+
+type Query struct {
+	Page   int
+	Size   int64
+	Score  float64
+	Debug  bool
+	Since  time.Time
+	Sort   string
+	Tags   []string
+	IDs    []int
+	Status string
+}
+
+func (q *Query) Params() []vform.Param {
+	return []vform.Param{
+		vform.Int("page", &q.Page, v.Gte(1)),
+		vform.Int64("size", &q.Size, v.Range[int64](1, 100)),
+		vform.Float64("score", &q.Score, nil),
+		vform.Bool("debug", &q.Debug, nil),
+		vform.Time("since", time.RFC3339, &q.Since, nil),
+		vform.String("sort", &q.Sort, v.In("asc", "desc")),
+		vform.Strings("tag", &q.Tags, v.LenSlice[[]string](0, 2)),
+		vform.Ints("id", &q.IDs, v.EachSlice[[]int](v.Gt(0))),
+		vform.Value("status", &q.Status, func(s string) (string, error) {
+			return strings.ToUpper(s), nil
+		}, v.In("OPEN", "CLOSED")),
+	}
+}
+
+func TestValues(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  Query
+		errs  v.Errors
+	}{
+		{
+			name:  "ok",
+			query: "page=2&size=10&score=1.5&debug=true&since=2022-01-02T03:04:05Z&sort=desc&tag=a&tag=b&id=1&id=2&status=open",
+			want: Query{
+				Page:   2,
+				Size:   10,
+				Score:  1.5,
+				Debug:  true,
+				Since:  time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+				Sort:   "desc",
+				Tags:   []string{"a", "b"},
+				IDs:    []int{1, 2},
+				Status: "OPEN",
+			},
+		},
+		{
+			name:  "defaults",
+			query: "page=&status=closed",
+			want: Query{
+				Page:   1,
+				Size:   20,
+				Sort:   "asc",
+				Status: "CLOSED",
+			},
+		},
+		{
+			name:  "errors",
+			query: "page=x&size=0&debug=maybe&since=today&sort=up&tag=a&tag=b&tag=c&id=1&id=y&status=draft",
+			want: Query{
+				Page:   1,
+				Size:   0,
+				Sort:   "up",
+				Tags:   []string{"a", "b", "c"},
+				Status: "DRAFT",
+			},
+			errs: v.Errors{
+				v.NewError("page", v.ErrInvalid, "must be of type integer"),
+				v.NewError("size", v.ErrInvalid, "is not between the given range"),
+				v.NewError("debug", v.ErrInvalid, "must be of type boolean"),
+				v.NewError("since", v.ErrInvalid, "must be a time formatted as 2006-01-02T15:04:05Z07:00"),
+				v.NewError("sort", v.ErrInvalid, "is not one of the given values"),
+				v.NewError("tag", v.ErrInvalid, "has an invalid length"),
+				v.NewError("id[1]", v.ErrInvalid, "must be of type integer"),
+				v.NewError("status", v.ErrInvalid, "is not one of the given values"),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			values, err := url.ParseQuery(c.query)
+			if err != nil {
+				t.Fatalf("ParseQuery err: %v", err)
+			}
+
+			q := Query{Page: 1, Size: 20, Sort: "asc"}
+			errs := v.Validate(vform.Values(values, q.Params()...))
+
+			var plain v.Errors
+			for _, err := range errs {
+				plain.Append(v.NewError(err.Field(), err.Kind(), err.Message()))
+			}
+			if !reflect.DeepEqual(plain, c.errs) {
+				t.Errorf("Got errs (%+v) != Want errs (%+v)", plain, c.errs)
+			}
+			if !reflect.DeepEqual(q, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", q, c.want)
+			}
+		})
+	}
+}
+
+func TestValues_Locale(t *testing.T) {
+	catalog := v.NewCatalog()
+	catalog.Register("de", map[string]string{
+		vform.CodeType:   "muss vom Typ {expected} sein",
+		vform.CodeFormat: "hat ein ungültiges Format",
+		vform.CodeLayout: "muss eine Zeit im Format {layout} sein",
+	})
+
+	values := url.Values{"page": {"x"}, "since": {"today"}, "status": {"draft"}}
+	var page int
+	var since time.Time
+	var status string
+	errs := v.Validate(vform.Values(values,
+		vform.Int("page", &page, nil),
+		vform.Time("since", "2006-01-02", &since, nil),
+		vform.Value("status", &status, func(s string) (string, error) {
+			return "", errors.New("bad status")
+		}, nil),
+	), v.WithLocale("de"), v.WithTranslator(catalog))
+
+	want := v.Errors{
+		v.NewCodedError("page", v.ErrInvalid, "muss vom Typ integer sein", vform.CodeType, map[string]any{"expected": "integer"}),
+		v.NewCodedError("since", v.ErrInvalid, "muss eine Zeit im Format 2006-01-02 sein", vform.CodeLayout, map[string]any{"layout": "2006-01-02"}),
+		v.NewCodedError("status", v.ErrInvalid, "hat ein ungültiges Format", vform.CodeFormat, nil),
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Got errs (%+v) != Want errs (%+v)", errs, want)
+	}
+}
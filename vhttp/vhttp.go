@@ -0,0 +1,195 @@
+// Package vhttp provides helpers for decoding and validating the bodies
+// of HTTP requests.
+package vhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/problem"
+	"github.com/RussellLuo/validating/v3/vjson"
+)
+
+// DecodeError is returned when the request body is not valid JSON.
+//
+// Note that type mismatches are not DecodeErrors, they are reported along
+// with the validation errors instead (see vjson.Unmarshal).
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return "vhttp: bad request body: " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorWriter writes the response for the given error, along with the
+// given status code.
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, status int, err error)
+
+// Options holds the options for Decode and Handler.
+type Options struct {
+	// Status returns the status code for the given error. Defaults to
+	// DefaultStatus.
+	Status func(err error) int
+
+	// ErrorWriter writes the error response. Defaults to WriteProblem.
+	ErrorWriter ErrorWriter
+
+	// ValidateOptions returns the options of the validation run for
+	// the given request, which are also used to report the decoding errors.
+	// Defaults to DefaultValidateOptions.
+	ValidateOptions func(r *http.Request) []v.Option
+
+	// DecodeOptions are the options for decoding the request body.
+	DecodeOptions []vjson.Option
+}
+
+// Option is used to customize Decode and Handler.
+type Option func(*Options)
+
+// WithStatus sets the function used to determine the status code of
+// an error response.
+func WithStatus(f func(err error) int) Option {
+	return func(o *Options) {
+		o.Status = f
+	}
+}
+
+// WithErrorWriter sets the writer used to write an error response.
+func WithErrorWriter(ew ErrorWriter) Option {
+	return func(o *Options) {
+		o.ErrorWriter = ew
+	}
+}
+
+// WithValidateOptions sets the function used to determine the options of
+// the validation run for each request.
+func WithValidateOptions(f func(r *http.Request) []v.Option) Option {
+	return func(o *Options) {
+		o.ValidateOptions = f
+	}
+}
+
+// WithDecodeOptions sets the options for decoding the request body.
+func WithDecodeOptions(opts ...vjson.Option) Option {
+	return func(o *Options) {
+		o.DecodeOptions = opts
+	}
+}
+
+func newOptions(opts []Option) *Options {
+	o := &Options{
+		Status:          DefaultStatus,
+		ErrorWriter:     WriteProblem,
+		ValidateOptions: DefaultValidateOptions,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// DefaultStatus returns 400 (Bad Request) for a *DecodeError, the status
+// determined by problem.Status for v.Errors, and 500 (Internal Server
+// Error) for any other error.
+func DefaultStatus(err error) int {
+	var decodeErr *DecodeError
+	var errs v.Errors
+	switch {
+	case errors.As(err, &decodeErr):
+		return http.StatusBadRequest
+	case errors.As(err, &errs):
+		return problem.Status(errs)
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// DefaultValidateOptions makes the error messages translated per the
+// Accept-Language header of the request.
+func DefaultValidateOptions(r *http.Request) []v.Option {
+	locales := v.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if len(locales) == 0 {
+		return nil
+	}
+	return []v.Option{v.WithLocale(locales...)}
+}
+
+// WriteProblem writes the given error as a problem details document.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+	d := &problem.Details{
+		Title:  problem.StatusText(status),
+		Status: status,
+	}
+
+	var errs v.Errors
+	switch {
+	case errors.As(err, &errs):
+		d = problem.New(errs)
+		d.Title = problem.StatusText(status)
+		d.Status = status
+	case status < http.StatusInternalServerError:
+		d.Detail = err.Error()
+	}
+
+	_ = d.Write(w)
+}
+
+// Decode decodes the JSON body of r into a value of type T, and then
+// validates the value per its schema, which is defined by the Schema method
+// of T or *T (see v.SchemaOf).
+//
+// The returned error is a *DecodeError if the body is not valid JSON,
+// or v.Errors if the decoding (i.e. type mismatches) or the validation
+// fails.
+func Decode[T any](r *http.Request, opts ...Option) (T, error) {
+	return decode[T](r, newOptions(opts))
+}
+
+func decode[T any](r *http.Request, o *Options) (body T, err error) {
+	// The decoding errors are reported by the same options as the
+	// validation errors, unless overridden by o.DecodeOptions.
+	validateOpts := o.ValidateOptions(r)
+	decodeOpts := append([]vjson.Option{vjson.WithValidateOptions(validateOpts...)}, o.DecodeOptions...)
+
+	var decodeErrs v.Errors
+	if err := vjson.Decode(r.Body, &body, decodeOpts...); err != nil {
+		if !errors.As(err, &decodeErrs) {
+			return body, &DecodeError{Err: err}
+		}
+	}
+
+	// The schema must be obtained after decoding, since it captures the
+	// values of the fields.
+	schema, ok := v.SchemaOf(&body)
+	if !ok {
+		return body, fmt.Errorf("vhttp: %T defines no validation schema", body)
+	}
+
+	errs := v.ValidateContext(r.Context(), schema, validateOpts...)
+	if errs = vjson.Merge(decodeErrs, errs); len(errs) > 0 {
+		return body, errs
+	}
+	return body, nil
+}
+
+// Handler returns an HTTP handler, which decodes and validates the request
+// body of type T before calling h. If either of them fails, an error
+// response will be written instead.
+func Handler[T any](h func(w http.ResponseWriter, r *http.Request, body T), opts ...Option) http.Handler {
+	o := newOptions(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := decode[T](r, o)
+		if err != nil {
+			o.ErrorWriter(w, r, o.Status(err), err)
+			return
+		}
+		h(w, r, body)
+	})
+}
@@ -0,0 +1,141 @@
+package vhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/vhttp"
+)
+
+type User struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func (u User) Schema() v.Schema {
+	return v.Schema{
+		v.F("name", u.Name): v.LenString(1, 5),
+	}
+}
+
+func TestHandler(t *testing.T) {
+	catalog := v.NewCatalog()
+	catalog.Register("de", map[string]string{
+		"type":       "muss vom Typ {expected} sein",
+		"len_string": "muss zwischen {min} und {max} Bytes lang sein",
+	})
+
+	cases := []struct {
+		name   string
+		opts   []vhttp.Option
+		body   string
+		status int
+		resp   string
+	}{
+		{
+			name:   "ok",
+			body:   `{"name":"foo","age":10}`,
+			status: http.StatusOK,
+			resp:   "foo",
+		},
+		{
+			name:   "bad body",
+			body:   `{"name":`,
+			status: http.StatusBadRequest,
+			resp:   `{"title":"Bad Request","status":400,"detail":"vhttp: bad request body: unexpected end of JSON input"}` + "\n",
+		},
+		{
+			name:   "type mismatch",
+			body:   `{"name":"","age":"10"}`,
+			status: http.StatusUnprocessableEntity,
+			resp: `{"title":"Unprocessable Entity","status":422,"detail":"The request parameters are invalid.","invalid-params":[` +
+				`{"name":"age","reason":"must be of type integer","code":"type"},` +
+				`{"name":"name","reason":"has an invalid length","code":"len_string"}` +
+				`]}` + "\n",
+		},
+		{
+			name: "type mismatch translated",
+			opts: []vhttp.Option{
+				vhttp.WithValidateOptions(func(r *http.Request) []v.Option {
+					return []v.Option{v.WithLocale("de"), v.WithTranslator(catalog)}
+				}),
+			},
+			body:   `{"name":"","age":"10"}`,
+			status: http.StatusUnprocessableEntity,
+			resp: `{"title":"Unprocessable Entity","status":422,"detail":"The request parameters are invalid.","invalid-params":[` +
+				`{"name":"age","reason":"muss vom Typ integer sein","code":"type"},` +
+				`{"name":"name","reason":"muss zwischen 1 und 5 Bytes lang sein","code":"len_string"}` +
+				`]}` + "\n",
+		},
+		{
+			name:   "invalid",
+			body:   `{"name":""}`,
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"title":"Unprocessable Entity","status":422,"detail":"The request parameters are invalid.","invalid-params":[{"name":"name","reason":"has an invalid length","code":"len_string"}]}` + "\n",
+		},
+		{
+			name: "custom status and writer",
+			opts: []vhttp.Option{
+				vhttp.WithStatus(func(err error) int { return http.StatusBadRequest }),
+				vhttp.WithErrorWriter(func(w http.ResponseWriter, r *http.Request, status int, err error) {
+					w.WriteHeader(status)
+					_, _ = w.Write([]byte(err.Error()))
+				}),
+			},
+			body:   `{"name":""}`,
+			status: http.StatusBadRequest,
+			resp:   "name: INVALID(has an invalid length)",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := vhttp.Handler(func(w http.ResponseWriter, r *http.Request, u User) {
+				_, _ = w.Write([]byte(u.Name))
+			}, c.opts...)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(c.body))
+			h.ServeHTTP(w, r)
+
+			if w.Code != c.status {
+				t.Errorf("Got status (%d) != Want status (%d)", w.Code, c.status)
+			}
+			if resp := w.Body.String(); resp != c.resp {
+				t.Errorf("Got resp (%s) != Want resp (%s)", resp, c.resp)
+			}
+		})
+	}
+}
+
+type Item struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (i *Item) Schema() v.OrderedSchema {
+	return v.OrderedSchema{}.
+		Add(v.F("name", i.Name), v.LenString(1, 5)).
+		Add(v.F("count", i.Count), v.Gte(1))
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("ordered schema", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"","count":0}`))
+		_, err := vhttp.Decode[Item](r)
+		want := "name: INVALID(has an invalid length), count: INVALID(is lower than the given value)"
+		if err == nil || err.Error() != want {
+			t.Errorf("Got (%v) != Want (%s)", err, want)
+		}
+	})
+
+	t.Run("no schema", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+		_, err := vhttp.Decode[map[string]any](r)
+		if status := vhttp.DefaultStatus(err); status != http.StatusInternalServerError {
+			t.Errorf("Got status (%d) != Want status (%d)", status, http.StatusInternalServerError)
+		}
+	})
+}
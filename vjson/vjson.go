@@ -0,0 +1,429 @@
+// Package vjson decodes JSON data, and reports decoding errors in the form
+// of validating.Errors, whose field names follow the same path convention
+// as the ones reported by the validation (e.g. "family[mother].name").
+package vjson
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+// Error codes of decoding errors.
+const (
+	CodeType         = "type"          // The JSON value has a mismatched type.
+	CodeUnknownField = "unknown_field" // The JSON object has an unknown key.
+)
+
+// Options holds the options for decoding.
+type Options struct {
+	// DisallowUnknownFields makes the decoding fail if an object has
+	// a key that does not match any exported struct field.
+	DisallowUnknownFields bool
+
+	// ValidateOptions are the options of the validation run, by which
+	// the decoding errors are reported (e.g. v.WithLocale).
+	ValidateOptions []v.Option
+}
+
+// Option is used to customize the decoding.
+type Option func(*Options)
+
+// DisallowUnknownFields makes the decoding fail if an object has a key that
+// does not match any exported struct field.
+func DisallowUnknownFields() Option {
+	return func(o *Options) {
+		o.DisallowUnknownFields = true
+	}
+}
+
+// WithValidateOptions sets the options of the validation run, by which
+// the decoding errors are reported. It's useful for translating the error
+// messages in the same way as the ones of the validation errors.
+func WithValidateOptions(opts ...v.Option) Option {
+	return func(o *Options) {
+		o.ValidateOptions = opts
+	}
+}
+
+// Decode reads all data from r, and then calls Unmarshal.
+func Decode(r io.Reader, ptr any, opts ...Option) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(data, ptr, opts...)
+}
+
+// Unmarshal parses the JSON-encoded data and stores the result in the
+// value pointed to by ptr, as json.Unmarshal does.
+//
+// A type mismatch (i.e. *json.UnmarshalTypeError), or an unknown field if
+// disallowed, is reported as v.Errors of kind INVALID, while the rest of
+// the data is still decoded into ptr. Like json.Unmarshal, only the first
+// of them is reported.
+//
+// Any other error (e.g. the data is not valid JSON) is returned as is.
+func Unmarshal(data []byte, ptr any, opts ...Option) error {
+	o := new(Options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if !json.Valid(data) {
+		// Let json.Unmarshal report the syntax error.
+		return json.Unmarshal(data, ptr)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	if o.DisallowUnknownFields {
+		d.DisallowUnknownFields()
+	}
+	err := d.Decode(ptr)
+
+	var s v.OrderedSchema
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &typeErr):
+		params := map[string]any{"expected": expectedType(typeErr.Type), "actual": actualType(typeErr.Value)}
+		path := typeErrorPath(data, reflect.TypeOf(ptr), typeErr)
+		s = s.Add(v.F(path, nil), v.Fail(CodeType, params))
+	default:
+		key, ok := unknownField(err)
+		if !ok {
+			return err
+		}
+		path := unknownFieldPath(data, reflect.TypeOf(ptr), key)
+		s = s.Add(v.F(path, nil), v.Fail(CodeUnknownField, nil))
+	}
+
+	if errs := v.Validate(s, o.ValidateOptions...); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Merge merges the given decoding errors and validation errors. Validation
+// errors on the fields that failed to decode, or on their descendants, are
+// dropped, since they are caused by the decoding errors.
+func Merge(decodeErrs, validateErrs v.Errors) v.Errors {
+	errs := append(v.Errors(nil), decodeErrs...)
+	for _, err := range validateErrs {
+		if !isCausedBy(err.Field(), decodeErrs) {
+			errs.Append(err)
+		}
+	}
+	return errs
+}
+
+func isCausedBy(field string, decodeErrs v.Errors) bool {
+	for _, err := range decodeErrs {
+		if err.Field() == "" {
+			return true
+		}
+		if isPathOrDescendant(field, err.Field()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPathOrDescendant(field, path string) bool {
+	if !strings.HasPrefix(field, path) {
+		return false
+	}
+	rest := field[len(path):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
+
+// unknownField returns the key reported by the given error, if it's
+// an unknown-field error returned by encoding/json.
+func unknownField(err error) (string, bool) {
+	const prefix = "json: unknown field "
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return "", false
+	}
+	key, err := strconv.Unquote(msg[len(prefix):])
+	return key, err == nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// expectedType returns the JSON type name of the given Go type.
+func expectedType(t reflect.Type) string {
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string.
+			return "string"
+		}
+		return "array"
+	case reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return t.String()
+	}
+}
+
+// actualType returns the JSON type name of the value described by
+// json.UnmarshalTypeError (e.g. "number" for "number -5").
+func actualType(value string) string {
+	if i := strings.IndexByte(value, ' '); i >= 0 {
+		value = value[:i]
+	}
+	if value == "bool" {
+		return "boolean"
+	}
+	return value
+}
+
+// element is an element of the path to a JSON value.
+type element struct {
+	key   string // The object key.
+	index int    // The array index, if array is true.
+	array bool
+}
+
+// walk calls f for each object key and each value of data in document order,
+// with the path to it (a key is the last element of its path) and the offset
+// right after it, until f returns false.
+func walk(data []byte, f func(path []element, isKey bool, end int64) bool) {
+	type container struct {
+		element
+		wantKey bool // Whether the next token of an object is a key.
+	}
+	var stack []*container
+
+	path := func() []element {
+		p := make([]element, len(stack))
+		for i, c := range stack {
+			p[i] = c.element
+		}
+		return p
+	}
+	next := func() {
+		if len(stack) == 0 {
+			return
+		}
+		if top := stack[len(stack)-1]; top.array {
+			top.index++
+		} else {
+			top.wantKey = true
+		}
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return
+		}
+
+		if n := len(stack); n > 0 && stack[n-1].wantKey {
+			if key, ok := tok.(string); ok {
+				stack[n-1].key = key
+				stack[n-1].wantKey = false
+				if !f(path(), true, d.InputOffset()) {
+					return
+				}
+				continue
+			}
+		}
+
+		switch tok {
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			next()
+			continue
+		}
+
+		if !f(path(), false, d.InputOffset()) {
+			return
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, &container{wantKey: true})
+		case json.Delim('['):
+			stack = append(stack, &container{element: element{array: true}})
+		default:
+			next()
+		}
+	}
+}
+
+// typeErrorPath returns the field name of the value, which failed to be
+// decoded into a value of type t.
+//
+// The value is located by the offset of the error, since the path reported
+// by json.UnmarshalTypeError varies among the versions of Go (e.g. whether
+// map keys and array indices are included).
+func typeErrorPath(data []byte, t reflect.Type, e *json.UnmarshalTypeError) string {
+	var path []element
+	walk(data, func(p []element, isKey bool, end int64) bool {
+		if isKey || end < e.Offset {
+			return true
+		}
+		path = p
+		return false
+	})
+
+	name, _, ok := resolve(t, path)
+	if !ok {
+		return e.Field
+	}
+	return name
+}
+
+// unknownFieldPath returns the field name of the first key of data, which
+// equals key and does not match any field of the struct (in a value of type
+// t) containing it.
+func unknownFieldPath(data []byte, t reflect.Type, key string) string {
+	path := key
+	walk(data, func(p []element, isKey bool, end int64) bool {
+		if !isKey || p[len(p)-1].key != key {
+			return true
+		}
+		name, st, ok := resolve(t, p[:len(p)-1])
+		if !ok || st.Kind() != reflect.Struct {
+			return true
+		}
+		if _, _, found := lookupField(st, key); found {
+			return true
+		}
+		path = joinField(name, key)
+		return false
+	})
+	return path
+}
+
+// resolve returns the field name of the JSON value at the given path, within
+// a value of type t, along with the Go type of the value (dereferenced). It
+// reports false if the path does not match t.
+func resolve(t reflect.Type, path []element) (string, reflect.Type, bool) {
+	var name string
+	for _, elem := range path {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		switch {
+		case elem.array && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+			name += "[" + strconv.Itoa(elem.index) + "]"
+			t = t.Elem()
+		case !elem.array && t.Kind() == reflect.Map:
+			name += "[" + elem.key + "]"
+			t = t.Elem()
+		case !elem.array && t.Kind() == reflect.Struct:
+			fieldName, ft, ok := lookupField(t, elem.key)
+			if !ok {
+				return "", nil, false
+			}
+			name = joinField(name, fieldName)
+			t = ft
+		default:
+			return "", nil, false
+		}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return name, t, true
+}
+
+// lookupField finds the field of struct type t by the given JSON key, and
+// returns its JSON name and type. Like encoding/json, fields of embedded
+// structs are promoted unless hidden by shallower fields, and an exact match
+// is preferred over a case-insensitive one.
+func lookupField(t reflect.Type, key string) (string, reflect.Type, bool) {
+	type field struct {
+		name string
+		typ  reflect.Type
+	}
+
+	var fields []field
+	seen := make(map[string]bool)
+	for level := []reflect.Type{t}; len(level) > 0; {
+		var next []reflect.Type
+		var names []string
+		for _, st := range level {
+			for i := 0; i < st.NumField(); i++ {
+				sf := st.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" || !sf.IsExported() && !sf.Anonymous {
+					continue
+				}
+				name, _, _ := strings.Cut(tag, ",")
+
+				ft := sf.Type
+				for ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+					next = append(next, ft)
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+
+				if name == "" {
+					name = sf.Name
+				}
+				if !seen[name] {
+					fields = append(fields, field{name: name, typ: sf.Type})
+					names = append(names, name)
+				}
+			}
+		}
+		// Fields at this level hide the ones with the same names deeper.
+		for _, name := range names {
+			seen[name] = true
+		}
+		level = next
+	}
+
+	for _, f := range fields {
+		if f.name == key {
+			return f.name, f.typ, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f.name, f.typ, true
+		}
+	}
+	return "", nil, false
+}
+
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,220 @@
+package vjson_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/vjson"
+)
+
+type Member struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type Base struct {
+	ID uint8 `json:"id"`
+}
+
+type Pet struct {
+	Base
+	Name string `json:"name"`
+}
+
+type Family struct {
+	Base
+	Family    map[string]Member `json:"family"`
+	Members   []Member          `json:"members"`
+	Animals   []*Pet            `json:"animals"`
+	Pets      []string          `json:"pets"`
+	CreatedAt time.Time         `json:"created_at"`
+	Count     int64             `json:"count,string"`
+	Ignored   string            `json:"-"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	catalog := v.NewCatalog()
+	catalog.Register("de", map[string]string{
+		vjson.CodeType: "muss vom Typ {expected} sein",
+	})
+
+	cases := []struct {
+		name string
+		data string
+		opts []vjson.Option
+		want Family
+		errs v.Errors
+	}{
+		{
+			name: "ok",
+			data: `{"id":1,"family":{"mother":{"name":"Alice","age":40}},"pets":["cat"],"count":"3","unknown":1}`,
+			want: Family{
+				Base:   Base{ID: 1},
+				Family: map[string]Member{"mother": {Name: "Alice", Age: 40}},
+				Pets:   []string{"cat"},
+				Count:  3,
+			},
+		},
+		{
+			name: "type error in embedded struct",
+			data: `{"id":256,"pets":["cat"]}`,
+			want: Family{
+				Pets: []string{"cat"},
+			},
+			errs: v.Errors{
+				v.NewCodedError("id", v.ErrInvalid, "must be of type integer", vjson.CodeType, map[string]any{"expected": "integer", "actual": "number"}),
+			},
+		},
+		{
+			name: "type error in map",
+			data: `{"family":{"father":{"name":"Bob"},"mother":{"name":"Alice","age":40.5}}}`,
+			want: Family{
+				Family: map[string]Member{"mother": {Name: "Alice"}, "father": {Name: "Bob"}},
+			},
+			errs: v.Errors{
+				v.NewCodedError("family[mother].age", v.ErrInvalid, "must be of type integer", vjson.CodeType, map[string]any{"expected": "integer", "actual": "number"}),
+			},
+		},
+		{
+			name: "type error in slice of structs",
+			data: `{"members":[{"name":"a"},{"name":1}]}`,
+			want: Family{
+				Members: []Member{{Name: "a"}, {}},
+			},
+			errs: v.Errors{
+				v.NewCodedError("members[1].name", v.ErrInvalid, "must be of type string", vjson.CodeType, map[string]any{"expected": "string", "actual": "number"}),
+			},
+		},
+		{
+			name: "type error in embedded struct in slice",
+			data: `{"animals":[{"id":1,"name":"a"},{"ID":300,"name":"b"}]}`,
+			want: Family{
+				Animals: []*Pet{{Base: Base{ID: 1}, Name: "a"}, {Name: "b"}},
+			},
+			errs: v.Errors{
+				v.NewCodedError("animals[1].id", v.ErrInvalid, "must be of type integer", vjson.CodeType, map[string]any{"expected": "integer", "actual": "number"}),
+			},
+		},
+		{
+			name: "type error of object",
+			data: `{"family":{"mother":"Alice"}}`,
+			want: Family{
+				Family: map[string]Member{"mother": {}},
+			},
+			errs: v.Errors{
+				v.NewCodedError("family[mother]", v.ErrInvalid, "must be of type object", vjson.CodeType, map[string]any{"expected": "object", "actual": "string"}),
+			},
+		},
+		{
+			name: "type error in slice",
+			data: `{"pets":["cat",["dog"],true],"count":"3"}`,
+			want: Family{
+				Pets:  []string{"cat", "", ""},
+				Count: 3,
+			},
+			errs: v.Errors{
+				v.NewCodedError("pets[1]", v.ErrInvalid, "must be of type string", vjson.CodeType, map[string]any{"expected": "string", "actual": "array"}),
+			},
+		},
+		{
+			name: "type error of root",
+			data: `["cat"]`,
+			errs: v.Errors{
+				v.NewCodedError("", v.ErrInvalid, "must be of type object", vjson.CodeType, map[string]any{"expected": "object", "actual": "array"}),
+			},
+		},
+		{
+			name: "unknown field in map",
+			data: `{"family":{"mother":{"name":"Alice","nickname":"A"}},"unknown":1}`,
+			opts: []vjson.Option{vjson.DisallowUnknownFields()},
+			want: Family{
+				Family: map[string]Member{"mother": {Name: "Alice"}},
+			},
+			errs: v.Errors{
+				v.NewCodedError("family[mother].nickname", v.ErrInvalid, "is not allowed", vjson.CodeUnknownField, nil),
+			},
+		},
+		{
+			name: "unknown field in slice",
+			data: `{"family":{"name":{"name":"Bob"}},"animals":[{"ID":1},{"name":"b","name2":"c"}]}`,
+			opts: []vjson.Option{vjson.DisallowUnknownFields()},
+			want: Family{
+				Family:  map[string]Member{"name": {Name: "Bob"}},
+				Animals: []*Pet{{Base: Base{ID: 1}}, {Name: "b"}},
+			},
+			errs: v.Errors{
+				v.NewCodedError("animals[1].name2", v.ErrInvalid, "is not allowed", vjson.CodeUnknownField, nil),
+			},
+		},
+		{
+			name: "translated",
+			data: `{"pets":[1]}`,
+			opts: []vjson.Option{vjson.WithValidateOptions(v.WithLocale("de"), v.WithTranslator(catalog))},
+			want: Family{
+				Pets: []string{""},
+			},
+			errs: v.Errors{
+				v.NewCodedError("pets[0]", v.ErrInvalid, "muss vom Typ string sein", vjson.CodeType, map[string]any{"expected": "string", "actual": "number"}),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got Family
+			err := vjson.Unmarshal([]byte(c.data), &got, c.opts...)
+
+			var errs v.Errors
+			if err != nil && !errors.As(err, &errs) {
+				t.Fatalf("Got unexpected err: %v", err)
+			}
+			if !reflect.DeepEqual(errs, c.errs) {
+				t.Errorf("Got errs (%+v) != Want errs (%+v)", errs, c.errs)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", got, c.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_OtherErrors(t *testing.T) {
+	var got Family
+	err := vjson.Unmarshal([]byte(`{"id":`), &got)
+
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Got err (%v) != Want *json.SyntaxError", err)
+	}
+
+	err = vjson.Unmarshal([]byte(`{"created_at":"today"}`), &got)
+
+	var parseErr *time.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("Got err (%v) != Want *time.ParseError", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	decodeErrs := v.Errors{
+		v.NewError("family[mother]", v.ErrInvalid, "must be of type object"),
+	}
+	validateErrs := v.Errors{
+		v.NewError("family[mother].name", v.ErrInvalid, "is zero valued"),
+		v.NewError("family[mother]x", v.ErrInvalid, "is invalid"),
+		v.NewError("pets", v.ErrInvalid, "has an invalid length"),
+	}
+
+	got := vjson.Merge(decodeErrs, validateErrs)
+	want := v.Errors{
+		v.NewError("family[mother]", v.ErrInvalid, "must be of type object"),
+		v.NewError("family[mother]x", v.ErrInvalid, "is invalid"),
+		v.NewError("pets", v.ErrInvalid, "has an invalid length"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got (%+v) != Want (%+v)", got, want)
+	}
+}
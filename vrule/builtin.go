@@ -0,0 +1,294 @@
+package vrule
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+var builtins = map[string]Factory{
+	"required":   zeroness("required", "is required", false),
+	"nonzero":    zeroness("nonzero", "is zero valued", false),
+	"zero":       zeroness("zero", "is nonzero", true),
+	"eq":         comparable1("eq", v.Eq[int64], v.Eq[uint64], v.Eq[float64], v.Eq[string], v.Eq[bool]),
+	"ne":         comparable1("ne", v.Ne[int64], v.Ne[uint64], v.Ne[float64], v.Ne[string], v.Ne[bool]),
+	"gt":         ordered1("gt", v.Gt[int64], v.Gt[uint64], v.Gt[float64], v.Gt[string]),
+	"gte":        ordered1("gte", v.Gte[int64], v.Gte[uint64], v.Gte[float64], v.Gte[string]),
+	"lt":         ordered1("lt", v.Lt[int64], v.Lt[uint64], v.Lt[float64], v.Lt[string]),
+	"lte":        ordered1("lte", v.Lte[int64], v.Lte[uint64], v.Lte[float64], v.Lte[string]),
+	"range":      rangeRule,
+	"in":         comparableN("in", v.In[int64], v.In[uint64], v.In[float64], v.In[string], v.In[bool]),
+	"nin":        comparableN("nin", v.Nin[int64], v.Nin[uint64], v.Nin[float64], v.Nin[string], v.Nin[bool]),
+	"len_string": length("len_string", v.LenString),
+	"rune_count": length("rune_count", v.RuneCount),
+	"len_slice":  lenSlice,
+	"match":      match,
+}
+
+// kind is the kind of the normalized values.
+type kind int
+
+const (
+	kindInt kind = iota
+	kindUint
+	kindFloat
+	kindString
+	kindBool
+	numKinds
+)
+
+// wants holds the values whose types are reported by unsupported errors.
+var wants = [numKinds]any{int64(0), uint64(0), float64(0), "", false}
+
+// normalize converts value, whose kind is any integer, floating-point,
+// string or boolean kind, to int64, uint64, float64, string or bool
+// respectively.
+func normalize(value any) (any, kind, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), kindInt, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), kindUint, true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), kindFloat, true
+	case reflect.String:
+		return rv.String(), kindString, true
+	case reflect.Bool:
+		return rv.Bool(), kindBool, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// variants holds the validators built for each kind of values. A validator
+// is nil if the rule's arguments can not be parsed for the kind.
+type variants [numKinds]v.Validator
+
+// validator creates a validator, which normalizes the field's value and
+// then delegates the validation to the variant of the value's kind.
+func (vs variants) validator(name string, args []string) (v.Validator, error) {
+	var desc v.Description
+	var supported []any
+	for _, k := range []kind{kindFloat, kindInt, kindUint, kindString, kindBool} {
+		if vs[k] != nil {
+			if supported == nil {
+				desc = v.Describe(vs[k])
+			}
+			supported = append(supported, wants[k])
+		}
+	}
+	if supported == nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidArgs, strings.Join(args, ","))
+	}
+
+	return v.WithDescription(v.Func(func(field *v.Field) v.Errors {
+		value, k, ok := normalize(field.Value)
+		if ok && vs[k] == nil {
+			// Fall back to compare integers as floating-point numbers,
+			// e.g. for `gte(0.5)`.
+			switch k {
+			case kindInt:
+				value, k = float64(value.(int64)), kindFloat
+			case kindUint:
+				value, k = float64(value.(uint64)), kindFloat
+			}
+		}
+		if !ok || vs[k] == nil {
+			return v.NewUnsupportedErrors(name, field, supported...)
+		}
+		return v.Value(value, vs[k]).Validate(field)
+	}), desc), nil
+}
+
+// build parses args for the kind, and then creates a validator by using f.
+// It returns nil if any argument can not be parsed.
+func build[T any](args []string, parse func(string) (T, error), f func([]T) v.Validator) v.Validator {
+	values := make([]T, len(args))
+	for i, arg := range args {
+		value, err := parse(arg)
+		if err != nil {
+			return nil
+		}
+		values[i] = value
+	}
+	return f(values)
+}
+
+func parseInt(s string) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+}
+
+func parseUint(s string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+}
+
+func parseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(s), 64)
+}
+
+func parseBool(s string) (bool, error) {
+	return strconv.ParseBool(strings.TrimSpace(s))
+}
+
+func parseString(s string) (string, error) {
+	return Unescape(s), nil
+}
+
+func wantArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("%w: want %d, got %d", ErrInvalidArgs, n, len(args))
+	}
+	return nil
+}
+
+func first[T any, V v.Validator](f func(T) V) func([]T) v.Validator {
+	return func(values []T) v.Validator { return f(values[0]) }
+}
+
+func all[T any, V v.Validator](f func(...T) V) func([]T) v.Validator {
+	return func(values []T) v.Validator { return f(values...) }
+}
+
+func both[T any, V v.Validator](f func(T, T) V) func([]T) v.Validator {
+	return func(values []T) v.Validator { return f(values[0], values[1]) }
+}
+
+func comparable1(name string, i func(int64) *v.MessageValidator, u func(uint64) *v.MessageValidator, f func(float64) *v.MessageValidator, s func(string) *v.MessageValidator, b func(bool) *v.MessageValidator) Factory {
+	return func(args ...string) (v.Validator, error) {
+		if err := wantArgs(args, 1); err != nil {
+			return nil, err
+		}
+		return variants{
+			kindInt:    build(args, parseInt, first(i)),
+			kindUint:   build(args, parseUint, first(u)),
+			kindFloat:  build(args, parseFloat, first(f)),
+			kindString: build(args, parseString, first(s)),
+			kindBool:   build(args, parseBool, first(b)),
+		}.validator(name, args)
+	}
+}
+
+func ordered1(name string, i func(int64) *v.MessageValidator, u func(uint64) *v.MessageValidator, f func(float64) *v.MessageValidator, s func(string) *v.MessageValidator) Factory {
+	return func(args ...string) (v.Validator, error) {
+		if err := wantArgs(args, 1); err != nil {
+			return nil, err
+		}
+		return variants{
+			kindInt:    build(args, parseInt, first(i)),
+			kindUint:   build(args, parseUint, first(u)),
+			kindFloat:  build(args, parseFloat, first(f)),
+			kindString: build(args, parseString, first(s)),
+		}.validator(name, args)
+	}
+}
+
+func comparableN(name string, i func(...int64) *v.MessageValidator, u func(...uint64) *v.MessageValidator, f func(...float64) *v.MessageValidator, s func(...string) *v.MessageValidator, b func(...bool) *v.MessageValidator) Factory {
+	return func(args ...string) (v.Validator, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%w: want at least 1, got 0", ErrInvalidArgs)
+		}
+		return variants{
+			kindInt:    build(args, parseInt, all(i)),
+			kindUint:   build(args, parseUint, all(u)),
+			kindFloat:  build(args, parseFloat, all(f)),
+			kindString: build(args, parseString, all(s)),
+			kindBool:   build(args, parseBool, all(b)),
+		}.validator(name, args)
+	}
+}
+
+func rangeRule(args ...string) (v.Validator, error) {
+	if err := wantArgs(args, 2); err != nil {
+		return nil, err
+	}
+	return variants{
+		kindInt:   build(args, parseInt, both(v.Range[int64])),
+		kindUint:  build(args, parseUint, both(v.Range[uint64])),
+		kindFloat: build(args, parseFloat, both(v.Range[float64])),
+	}.validator("range", args)
+}
+
+// bounds parses the minimum and maximum lengths, where an empty maximum
+// means no limit (e.g. `len_string(1,)`).
+func bounds(args []string) (min, max int, err error) {
+	if err := wantArgs(args, 2); err != nil {
+		return 0, 0, err
+	}
+	min, err = strconv.Atoi(strings.TrimSpace(args[0]))
+	if err != nil || min < 0 {
+		return 0, 0, fmt.Errorf("%w: min must be a non-negative integer", ErrInvalidArgs)
+	}
+	max = math.MaxInt
+	if s := strings.TrimSpace(args[1]); s != "" {
+		max, err = strconv.Atoi(s)
+		if err != nil || max < min {
+			return 0, 0, fmt.Errorf("%w: max must be an integer no less than min", ErrInvalidArgs)
+		}
+	}
+	return min, max, nil
+}
+
+func length(name string, f func(min, max int) *v.MessageValidator) Factory {
+	return func(args ...string) (v.Validator, error) {
+		min, max, err := bounds(args)
+		if err != nil {
+			return nil, err
+		}
+		return variants{kindString: f(min, max)}.validator(name, args)
+	}
+}
+
+func lenSlice(args ...string) (v.Validator, error) {
+	min, max, err := bounds(args)
+	if err != nil {
+		return nil, err
+	}
+	validator := v.LenSlice[[]any](min, max)
+	return v.WithDescription(v.Func(func(field *v.Field) v.Errors {
+		rv := reflect.ValueOf(field.Value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return v.NewUnsupportedErrors("len_slice", field, []any(nil))
+		}
+		// Only the length matters, hence the elements are left as nil.
+		return v.Value(make([]any, rv.Len()), validator).Validate(field)
+	}), v.Describe(validator)), nil
+}
+
+func match(args ...string) (v.Validator, error) {
+	re, err := compile(args)
+	if err != nil {
+		return nil, err
+	}
+	return variants{kindString: v.Match(re)}.validator("match", args)
+}
+
+func isZero(value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
+
+// zeroness creates a factory for the rules checking the zero-ness of values
+// of any type.
+func zeroness(code, message string, zero bool) Factory {
+	return func(args ...string) (v.Validator, error) {
+		if err := wantArgs(args, 0); err != nil {
+			return nil, err
+		}
+		var mv *v.MessageValidator
+		mv = &v.MessageValidator{
+			Message: message,
+			Code:    code,
+			Validator: v.Func(func(field *v.Field) v.Errors {
+				if isZero(field.Value) != zero {
+					return mv.Invalid(field)
+				}
+				return nil
+			}),
+		}
+		return mv, nil
+	}
+}
@@ -0,0 +1,292 @@
+// Package vrule builds validators from compact rule strings, which makes it
+// possible to configure validation without changing code.
+//
+// A rule string consists of comma-separated rules, each of which is a name
+// optionally followed by parenthesized arguments:
+//
+//	required,len_string(1,64),match(^[a-z]+$)
+//
+// The arguments of a rule are also separated by commas, except for nested
+// parentheses (e.g. in a regular expression) and characters escaped by a
+// backslash. Spaces around the arguments are ignored, unless escaped (e.g.
+// in(\ a,b) for " a" and "b").
+package vrule
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	v "github.com/RussellLuo/validating/v3"
+)
+
+var (
+	// ErrUnknownRule is returned when a rule is not registered.
+	ErrUnknownRule = errors.New("unknown rule")
+
+	// ErrInvalidArgs is returned when the arguments of a rule are invalid.
+	ErrInvalidArgs = errors.New("invalid arguments")
+
+	// ErrSyntax is returned when a rule string is malformed.
+	ErrSyntax = errors.New("syntax error")
+)
+
+// Factory creates a validator from the arguments of a rule. It should return
+// an error wrapping ErrInvalidArgs if the arguments are invalid.
+type Factory func(args ...string) (v.Validator, error)
+
+// Registry maps rule names to validator factories.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry creates a registry, which has the built-in rules registered:
+//
+//	required              the value is not nil or zero valued
+//	nonzero, zero         the value is (not) zero valued
+//	eq(x), ne(x)          the value does (not) equal x
+//	gt(x), gte(x)         the value is greater than (or equal to) x
+//	lt(x), lte(x)         the value is lower than (or equal to) x
+//	range(min,max)        the value is between min and max
+//	in(x,...), nin(x,...) the value is (not) one of the given values
+//	len_string(min,max)   the length of the string is between min and max
+//	rune_count(min,max)   the number of runes is between min and max
+//	len_slice(min,max)    the length of the slice is between min and max
+//	match(pattern)        the string matches the regular expression
+//
+// Values of any integer, floating-point, string or boolean kind are
+// supported, and the arguments are parsed according to the value's kind.
+//
+// Note that the rule required, which follows the convention of tag-based
+// libraries, means nonzero (e.g. false and 0 are rejected). It differs from
+// v.Required, which only rejects absent values (e.g. nil pointers).
+func NewRegistry() *Registry {
+	r := &Registry{factories: make(map[string]Factory)}
+	for name, f := range builtins {
+		r.factories[name] = f
+	}
+	return r
+}
+
+// Register registers the factory for the rule with the given name, which
+// replaces any existing one.
+func (r *Registry) Register(name string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[name] = factory
+}
+
+// Names returns the names of all the registered rules in order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse builds a validator from the given rule string. The validator will
+// succeed when all rules succeed, and will return the error from the first
+// failed rule otherwise.
+func (r *Registry) Parse(rules string) (v.Validator, error) {
+	parsed, err := ParseRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var validators []v.Validator
+	for _, rule := range parsed {
+		validator, err := r.build(rule)
+		if err != nil {
+			return nil, fmt.Errorf("vrule: %q: %w", rules, err)
+		}
+		validators = append(validators, validator)
+	}
+
+	if len(validators) == 1 {
+		return validators[0], nil
+	}
+	return v.All(validators...), nil
+}
+
+// MustParse is like Parse but panics if the rule string is invalid.
+func (r *Registry) MustParse(rules string) v.Validator {
+	validator, err := r.Parse(rules)
+	if err != nil {
+		panic(err)
+	}
+	return validator
+}
+
+func (r *Registry) build(rule Rule) (v.Validator, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[rule.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRule, rule.Name)
+	}
+
+	validator, err := factory(rule.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+	}
+	return validator, nil
+}
+
+// Rule is a rule parsed from a rule string.
+type Rule struct {
+	Name string
+	// Args are the raw arguments, which are neither trimmed nor unescaped.
+	Args []string
+}
+
+// ParseRules parses the given rule string into rules, without checking
+// whether they are registered. It's useful for tools which handle rule
+// strings by themselves (e.g. code generators).
+func ParseRules(rules string) ([]Rule, error) {
+	tokens, err := split(rules)
+	if err != nil {
+		return nil, fmt.Errorf("vrule: %q: %w", rules, err)
+	}
+
+	parsed := make([]Rule, 0, len(tokens))
+	for _, token := range tokens {
+		rule, err := parseRule(strings.TrimSpace(token))
+		if err != nil {
+			return nil, fmt.Errorf("vrule: %q: %w", rules, err)
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+func parseRule(token string) (Rule, error) {
+	if token == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrSyntax)
+	}
+
+	i := strings.IndexByte(token, '(')
+	if i < 0 {
+		return Rule{Name: token}, nil
+	}
+	if !strings.HasSuffix(token, ")") {
+		return Rule{}, fmt.Errorf("%w: unexpected characters after %q", ErrSyntax, token[:strings.LastIndexByte(token, ')')+1])
+	}
+
+	rule := Rule{Name: strings.TrimSpace(token[:i])}
+	if inner := token[i+1 : len(token)-1]; inner != "" {
+		args, err := split(inner)
+		if err != nil {
+			return Rule{}, err
+		}
+		rule.Args = args
+	}
+	return rule, nil
+}
+
+// Unescape removes the backslashes escaping the characters in the given
+// argument, after trimming the leading and trailing spaces, which are not
+// escaped.
+func Unescape(arg string) string {
+	start, end := 0, len(arg)
+	for start < end && isSpace(arg[start]) {
+		start++
+	}
+	for end > start && isSpace(arg[end-1]) && !isEscaped(arg, end-1) {
+		end--
+	}
+	arg = arg[start:end]
+
+	if !strings.Contains(arg, `\`) {
+		return arg
+	}
+	var b strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if arg[i] == '\\' && i+1 < len(arg) {
+			i++
+		}
+		b.WriteByte(arg[i])
+	}
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// isEscaped reports whether the i-th character of s is escaped, i.e. it's
+// preceded by an odd number of backslashes.
+func isEscaped(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// split splits s by the commas, which are neither in parentheses nor
+// escaped by a backslash.
+func split(s string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // Skip the escaped character.
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%w: unbalanced parentheses", ErrSyntax)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses", ErrSyntax)
+	}
+	return append(parts, s[start:]), nil
+}
+
+// Default is the default registry used by Register, Parse and MustParse.
+var Default = NewRegistry()
+
+// Register registers the factory for the rule with the given name in the
+// default registry.
+func Register(name string, factory Factory) {
+	Default.Register(name, factory)
+}
+
+// Parse builds a validator from the given rule string by using the default
+// registry.
+func Parse(rules string) (v.Validator, error) {
+	return Default.Parse(rules)
+}
+
+// MustParse is like Parse but panics if the rule string is invalid.
+func MustParse(rules string) v.Validator {
+	return Default.MustParse(rules)
+}
+
+// compile compiles the pattern of the match rule.
+func compile(args []string) (*regexp.Regexp, error) {
+	// The pattern may contain commas, which have been split as separators.
+	re, err := regexp.Compile(strings.Join(args, ","))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,198 @@
+package vrule_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/vrule"
+)
+
+type Age int
+
+type result struct {
+	Kind    string
+	Message string
+	Code    string
+}
+
+func toResult(errs v.Errors) *result {
+	if len(errs) == 0 {
+		return nil
+	}
+	r := &result{Kind: errs[0].Kind(), Message: errs[0].Message()}
+	if ce, ok := errs[0].(v.CodedError); ok {
+		r.Code = ce.Code()
+	}
+	return r
+}
+
+func TestParse(t *testing.T) {
+	invalid := func(code, msg string) *result {
+		return &result{Kind: v.ErrInvalid, Message: msg, Code: code}
+	}
+
+	cases := []struct {
+		rules string
+		value any
+		want  *result
+	}{
+		{rules: "required", value: "a"},
+		{rules: "required", value: "", want: invalid("required", "is required")},
+		{rules: "required", value: (*int)(nil), want: invalid("required", "is required")},
+		{rules: "nonzero", value: []int{}},
+		{rules: "zero", value: 1, want: invalid("zero", "is nonzero")},
+		{rules: "gte(10)", value: 10},
+		{rules: "gte(10)", value: Age(9), want: invalid("gte", "is lower than the given value")},
+		{rules: "gte(10)", value: uint8(11)},
+		{rules: "gt(0.5)", value: 1},
+		{rules: "lt(b)", value: "a"},
+		{rules: "lte(1.5)", value: 1.6, want: invalid("lte", "is greater than the given value")},
+		{rules: "eq(true)", value: false, want: invalid("eq", "does not equal the given value")},
+		{rules: "ne(x)", value: "y"},
+		{rules: "range(1, 10)", value: int64(5)},
+		{rules: "range(1, 10)", value: 11.0, want: invalid("range", "is not between the given range")},
+		{rules: "in(a,b\\,c)", value: "b,c"},
+		{rules: "in(asc, desc)", value: "desc"},
+		{rules: "in(\\ a,b)", value: " a"},
+		{rules: "in(\\ a,b)", value: "a", want: invalid("in", "is not one of the given values")},
+		{rules: "eq( a\\ )", value: "a "},
+		{rules: "in(1,2)", value: 3, want: invalid("in", "is not one of the given values")},
+		{rules: "nin(1,2)", value: 2, want: invalid("nin", "is one of the given values")},
+		{rules: "len_string(1,3)", value: "abcd", want: invalid("len_string", "has an invalid length")},
+		{rules: "len_string(1,)", value: "abcd"},
+		{rules: "rune_count(1,2)", value: "你好"},
+		{rules: "len_slice(0,1)", value: []string{"a", "b"}, want: invalid("len_slice", "has an invalid length")},
+		{rules: "match(^a{1,3}(b|c)$)", value: "aab"},
+		{rules: "match(^[a-z]+$)", value: "A", want: invalid("match", "does not match the given regular expression")},
+		{
+			rules: "required,len_string(1,64),match(^[a-z]+$)",
+			value: "",
+			want:  invalid("required", "is required"),
+		},
+		{
+			rules: "required,len_string(1,64),match(^[a-z]+$)",
+			value: "abc1",
+			want:  invalid("match", "does not match the given regular expression"),
+		},
+		{
+			rules: "gte(1)",
+			value: []int{1},
+			want:  &result{Kind: v.ErrUnsupported, Message: "gte expected float64 or int64 or uint64 or string but got []int"},
+		},
+		{
+			rules: "range(1,2)",
+			value: "a",
+			want:  &result{Kind: v.ErrUnsupported, Message: "range expected float64 or int64 or uint64 but got string"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.rules, func(t *testing.T) {
+			validator, err := vrule.Parse(c.rules)
+			if err != nil {
+				t.Fatalf("Parse err: %v", err)
+			}
+			got := toResult(v.Validate(v.Value(c.value, validator)))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", got, c.want)
+			}
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	cases := []struct {
+		rules   string
+		wantErr error
+		wantMsg string
+	}{
+		{
+			rules:   "required,foo",
+			wantErr: vrule.ErrUnknownRule,
+			wantMsg: `vrule: "required,foo": unknown rule "foo"`,
+		},
+		{
+			rules:   "range(1)",
+			wantErr: vrule.ErrInvalidArgs,
+			wantMsg: `vrule: "range(1)": rule "range": invalid arguments: want 2, got 1`,
+		},
+		{
+			rules:   "range(a,b)",
+			wantErr: vrule.ErrInvalidArgs,
+			wantMsg: `vrule: "range(a,b)": rule "range": invalid arguments: "a,b"`,
+		},
+		{
+			rules:   "len_string(3,1)",
+			wantErr: vrule.ErrInvalidArgs,
+			wantMsg: `vrule: "len_string(3,1)": rule "len_string": invalid arguments: max must be an integer no less than min`,
+		},
+		{
+			rules:   "match([)",
+			wantErr: vrule.ErrInvalidArgs,
+			wantMsg: "vrule: \"match([)\": rule \"match\": invalid arguments: error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			rules:   "required(1)",
+			wantErr: vrule.ErrInvalidArgs,
+			wantMsg: `vrule: "required(1)": rule "required": invalid arguments: want 0, got 1`,
+		},
+		{
+			rules:   "gte(1",
+			wantErr: vrule.ErrSyntax,
+			wantMsg: `vrule: "gte(1": syntax error: unbalanced parentheses`,
+		},
+		{
+			rules:   "required,,gte(1)",
+			wantErr: vrule.ErrSyntax,
+			wantMsg: `vrule: "required,,gte(1)": syntax error: empty rule`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.rules, func(t *testing.T) {
+			_, err := vrule.Parse(c.rules)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("Got (%v) is not Want (%v)", err, c.wantErr)
+			}
+			if err.Error() != c.wantMsg {
+				t.Errorf("Got (%s) != Want (%s)", err, c.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := vrule.NewRegistry()
+	r.Register("even", func(args ...string) (v.Validator, error) {
+		return v.Is(func(i int) bool { return i%2 == 0 }), nil
+	})
+
+	validator := r.MustParse("gte(0),even")
+	if errs := v.Validate(v.Value(2, validator)); errs != nil {
+		t.Errorf("Got (%v) != Want (nil)", errs)
+	}
+	if errs := v.Validate(v.Value(3, validator)); len(errs) != 1 {
+		t.Errorf("Got (%v) != Want (1 error)", errs)
+	}
+
+	if _, err := vrule.Parse("even"); !errors.Is(err, vrule.ErrUnknownRule) {
+		t.Errorf("Got (%v) is not Want (%v)", err, vrule.ErrUnknownRule)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	got, err := vrule.ParseRules(`required, in(a, b\,c),match(^a{1,2}$)`)
+	if err != nil {
+		t.Fatalf("ParseRules err: %v", err)
+	}
+	want := []vrule.Rule{
+		{Name: "required"},
+		{Name: "in", Args: []string{"a", " b\\,c"}},
+		{Name: "match", Args: []string{"^a{1", "2}$"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got (%+v) != Want (%+v)", got, want)
+	}
+}
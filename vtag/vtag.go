@@ -0,0 +1,383 @@
+// Package vtag builds schemas from struct tags via reflection, which eases
+// the migration from tag-based validation libraries.
+//
+// The rules are specified in the `validate` tag by using the syntax of
+// package vrule, along with the following special tokens:
+//
+//	omitempty  skip the rules if the field's value is zero valued
+//	dive       apply the subsequent rules to the elements of a slice,
+//	           an array or a map
+//
+// For example:
+//
+//	type User struct {
+//		Name    string   `json:"name" validate:"required,len_string(1,64)"`
+//		Email   string   `json:"email" validate:"omitempty,match(@)"`
+//		Tags    []string `json:"tags" validate:"len_slice(0,10),dive,len_string(1,20)"`
+//		Address *Address `json:"address"`
+//	}
+//
+// A field is named after its JSON name if any, or its Go name otherwise.
+// Nested structs (also via pointers, slices, arrays and maps) are always
+// validated recursively, and nil pointers are skipped, unless the nested
+// struct is tagged with `validate:"-"`.
+package vtag
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/vrule"
+)
+
+// TagName is the name of the struct tag holding the rules.
+const TagName = "validate"
+
+// Schema builds the schema for the given struct, or pointer to struct,
+// according to its struct tags. The rules are parsed by using the default
+// registry of package vrule, and Schema panics if they are invalid.
+//
+// The fields are validated in their declaration order, and the tags are
+// parsed only once for each struct type.
+func Schema(value any) v.OrderedSchema {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("vtag: value must be a struct or a pointer to struct, got %T", value))
+	}
+	return infoOf(rv.Type()).schema(rv)
+}
+
+// Validate validates the given struct, or pointer to struct, according to
+// its struct tags.
+func Validate(value any, opts ...v.Option) v.Errors {
+	return v.Validate(Schema(value), opts...)
+}
+
+// structInfo holds the parsed fields of a struct type.
+type structInfo struct {
+	fields []fieldInfo
+}
+
+type fieldInfo struct {
+	index     []int
+	typ       reflect.Type
+	name      string
+	validator v.Validator
+}
+
+func (si *structInfo) schema(rv reflect.Value) v.OrderedSchema {
+	s := make(v.OrderedSchema, 0, len(si.fields))
+	for _, f := range si.fields {
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil {
+			// A field of a nil embedded pointer, which is zero valued.
+			fv = reflect.Zero(f.typ)
+		}
+		s = s.Add(v.F(f.name, fv.Interface()), f.validator)
+	}
+	return s
+}
+
+var cache sync.Map // map[reflect.Type]*structInfo
+
+func infoOf(t reflect.Type) *structInfo {
+	if si, ok := cache.Load(t); ok {
+		return si.(*structInfo)
+	}
+	si, _ := cache.LoadOrStore(t, parseStruct(t))
+	return si.(*structInfo)
+}
+
+func parseStruct(t reflect.Type) *structInfo {
+	si := new(structInfo)
+	visited := map[reflect.Type]bool{t: true}
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get(TagName)
+			if tag == "-" || !sf.IsExported() && !sf.Anonymous {
+				continue
+			}
+
+			fieldIndex := append(append([]int(nil), index...), i)
+			name, hasName := jsonName(sf)
+
+			// Flatten embedded structs (or pointers to them), as encoding/json
+			// does. Embedding a visited type again would never end.
+			if et := derefType(sf.Type); sf.Anonymous && !hasName && tag == "" && et.Kind() == reflect.Struct {
+				if !visited[et] {
+					visited[et] = true
+					walk(et, fieldIndex)
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+
+			validator, err := parseField(sf.Type, tag)
+			if err != nil {
+				panic(fmt.Sprintf("vtag: field %s.%s: %v", t, sf.Name, err))
+			}
+			if validator != nil {
+				si.fields = append(si.fields, fieldInfo{index: fieldIndex, typ: sf.Type, name: name, validator: validator})
+			}
+		}
+	}
+	walk(t, nil)
+	return si
+}
+
+// jsonName returns the JSON name of the struct field.
+func jsonName(sf reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name, false
+	}
+	return name, true
+}
+
+// parseField builds the validator for the field of type t with the given
+// tag. It returns nil if there is nothing to validate.
+func parseField(t reflect.Type, tag string) (v.Validator, error) {
+	rules, dive, hasDive := cut(tag, "dive")
+
+	omitEmpty := false
+	if before, after, ok := cut(rules, "omitempty"); ok {
+		omitEmpty = true
+		rules = strings.Trim(before+","+after, ",")
+	}
+
+	var validators []v.Validator
+	if rules != "" {
+		validator, err := vrule.Parse(rules)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, deref(validator))
+	}
+
+	if hasDive {
+		ct := indirect(t)
+		switch ct.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+		default:
+			return nil, fmt.Errorf("dive on non-container type %s", t)
+		}
+		elem, err := parseField(ct.Elem(), dive)
+		if err != nil {
+			return nil, err
+		}
+		if elem != nil {
+			validators = append(validators, elements(ct, elem))
+		}
+	} else if n := nested(indirect(t)); n != nil {
+		validators = append(validators, n)
+	}
+
+	var validator v.Validator
+	switch len(validators) {
+	case 0:
+		return nil, nil
+	case 1:
+		validator = validators[0]
+	default:
+		validator = v.All(validators...)
+	}
+
+	if omitEmpty {
+		validator = omitZero(validator)
+	}
+	return validator, nil
+}
+
+// cut slices tag around the first rule named token, which is not in
+// parentheses (e.g. as an argument of a rule).
+func cut(tag, token string) (before, after string, found bool) {
+	depth := 0
+	start := 0
+	for i := 0; i <= len(tag); i++ {
+		if i < len(tag) {
+			switch tag[i] {
+			case '\\':
+				i++
+				continue
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				continue
+			case ',':
+				if depth != 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if strings.TrimSpace(tag[start:i]) == token {
+			before = strings.TrimSuffix(tag[:start], ",")
+			if i < len(tag) {
+				after = tag[i+1:]
+			}
+			return before, after, true
+		}
+		start = i + 1
+	}
+	return tag, "", false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// deref creates a validator, which applies validator to the value pointed
+// to by the field's value if it's a non-nil pointer.
+func deref(validator v.Validator) v.Validator {
+	return v.WithDescription(v.Func(func(field *v.Field) v.Errors {
+		rv := reflect.ValueOf(field.Value)
+		if rv.Kind() != reflect.Pointer || rv.IsNil() {
+			return validator.Validate(field)
+		}
+		for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		return v.Value(rv.Interface(), validator).Validate(field)
+	}), v.Describe(validator))
+}
+
+// omitZero creates a validator, which applies validator only if the
+// field's value is neither nil nor zero valued.
+func omitZero(validator v.Validator) v.Validator {
+	return v.WithDescription(v.Func(func(field *v.Field) v.Errors {
+		if field.Value == nil || reflect.ValueOf(field.Value).IsZero() {
+			return nil
+		}
+		return validator.Validate(field)
+	}), v.Describe(validator))
+}
+
+// nested returns the validator for values of type t, which contain structs
+// to be validated recursively, or nil if there is none.
+func nested(t reflect.Type) v.Validator {
+	switch t.Kind() {
+	case reflect.Struct:
+		// The struct info is resolved lazily, which allows recursive types.
+		return v.Func(func(field *v.Field) v.Errors {
+			rv := reflect.ValueOf(field.Value)
+			for rv.Kind() == reflect.Pointer {
+				if rv.IsNil() {
+					return nil
+				}
+				rv = rv.Elem()
+			}
+			return infoOf(rv.Type()).schema(rv).Validate(field)
+		})
+	case reflect.Pointer:
+		return nested(indirect(t))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if elem := nested(indirect(t.Elem())); elem != nil {
+			return elements(t, elem)
+		}
+	}
+	return nil
+}
+
+// elements creates a validator, which applies validator to each element of
+// the slice, array or map of type t, or to the struct itself otherwise.
+func elements(t reflect.Type, validator v.Validator) v.Validator {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		each := v.EachSlice[[]any](validator)
+		return v.Func(func(field *v.Field) v.Errors {
+			rv := indirectValue(field.Value)
+			if !rv.IsValid() {
+				return nil
+			}
+			s := make([]any, rv.Len())
+			for i := range s {
+				s[i] = rv.Index(i).Interface()
+			}
+			return v.Value(s, each).Validate(field)
+		})
+	case reflect.Map:
+		each := v.EachMap[map[string]any](validator)
+		return v.Func(func(field *v.Field) (errs v.Errors) {
+			rv := indirectValue(field.Value)
+			if !rv.IsValid() {
+				return nil
+			}
+			// Validate the elements one by one, in the same order as v.EachMap
+			// does for the original keys, since the keys are named by their
+			// string representations.
+			for _, k := range sortedKeys(rv) {
+				m := map[string]any{fmt.Sprintf("%v", k.Interface()): rv.MapIndex(k).Interface()}
+				errs.Append(v.Value(m, each).Validate(field)...)
+			}
+			return errs
+		})
+	default:
+		return validator
+	}
+}
+
+// sortedKeys returns the sorted keys of the map value rv. As in v.EachMap,
+// keys of the same ordered kind (i.e. integers, floats and strings) are
+// compared natively, while the others are compared by their string
+// representations.
+func sortedKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Kind() == reflect.Interface {
+			a, b = a.Elem(), b.Elem()
+		}
+		if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			case reflect.String:
+				return a.String() < b.String()
+			}
+		}
+		return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+	})
+	return keys
+}
+
+// derefType returns the type pointed to by t, if t is a pointer type.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// indirectValue returns the value pointed to by value, or an invalid value
+// if value is a nil pointer.
+func indirectValue(value any) reflect.Value {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
@@ -0,0 +1,214 @@
+package vtag_test
+
+import (
+	"reflect"
+	"testing"
+
+	v "github.com/RussellLuo/validating/v3"
+	"github.com/RussellLuo/validating/v3/vtag"
+)
+
+type Address struct {
+	Country string `json:"country" validate:"required"`
+	City    string `json:"city" validate:"in(A,B)"`
+}
+
+type Base struct {
+	ID int `json:"id" validate:"gte(1)"`
+}
+
+type Node struct {
+	Name     string `validate:"required"`
+	Children []Node
+}
+
+type Person struct {
+	Base
+	Name     string             `json:"name,omitempty" validate:"required,len_string(1,5)"`
+	Age      *int               `json:"age" validate:"omitempty,range(0,150)"`
+	Email    string             `json:"email" validate:"omitempty,match(^[a-z]+@[a-z]+$)"`
+	Tags     []string           `json:"tags" validate:"len_slice(0,2),dive,len_string(1,3)"`
+	Scores   map[string]float64 `json:"scores" validate:"dive,gte(0)"`
+	Address  *Address           `json:"address"`
+	Backups  []Address          `json:"backups"`
+	Contacts map[string]*Address
+	Tree     Node    `json:"tree"`
+	Ignored  Address `validate:"-"`
+	internal string  `validate:"required"`
+}
+
+func intPtr(i int) *int { return &i }
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		value any
+		want  map[string]string
+	}{
+		{
+			name: "valid",
+			value: &Person{
+				Base:    Base{ID: 1},
+				Name:    "foo",
+				Tags:    []string{"a"},
+				Scores:  map[string]float64{"x": 1},
+				Address: &Address{Country: "X", City: "A"},
+				Tree:    Node{Name: "root", Children: []Node{{Name: "leaf"}}},
+			},
+		},
+		{
+			name: "invalid",
+			value: Person{
+				Name:     "foobar",
+				Age:      intPtr(200),
+				Email:    "x",
+				Tags:     []string{"a", "abcd"},
+				Scores:   map[string]float64{"x": -1},
+				Address:  &Address{City: "C"},
+				Backups:  []Address{{Country: "X", City: "A"}, {City: "A"}},
+				Contacts: map[string]*Address{"home": {Country: "X"}, "work": nil},
+				Tree:     Node{Name: "root", Children: []Node{{}}},
+			},
+			want: map[string]string{
+				"id":                    "is lower than the given value",
+				"name":                  "has an invalid length",
+				"age":                   "is not between the given range",
+				"email":                 "does not match the given regular expression",
+				"tags[1]":               "has an invalid length",
+				"scores[x]":             "is lower than the given value",
+				"address.country":       "is required",
+				"address.city":          "is not one of the given values",
+				"backups[1].country":    "is required",
+				"Contacts[home].city":   "is not one of the given values",
+				"tree.Children[0].Name": "is required",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got map[string]string
+			for _, err := range vtag.Validate(c.value) {
+				if got == nil {
+					got = make(map[string]string)
+				}
+				got[err.Field()] = err.Message()
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", got, c.want)
+			}
+		})
+	}
+}
+
+func TestValidate_Order(t *testing.T) {
+	value := Person{
+		Name:    "foobar",
+		Age:     intPtr(200),
+		Email:   "x",
+		Address: &Address{City: "C"},
+		Backups: []Address{{}},
+	}
+	want := []string{"id", "name", "age", "email", "address.country", "address.city", "backups[0].country", "backups[0].city", "tree.Name"}
+
+	// Run several times, since the order of map iteration is random.
+	for i := 0; i < 10; i++ {
+		var got []string
+		for _, err := range vtag.Validate(value) {
+			got = append(got, err.Field())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Got (%+v) != Want (%+v)", got, want)
+		}
+	}
+}
+
+func TestSchema_Panic(t *testing.T) {
+	type Bad struct {
+		Name string `validate:"unknown"`
+	}
+
+	cases := []struct {
+		value any
+		want  string
+	}{
+		{value: 1, want: "vtag: value must be a struct or a pointer to struct, got int"},
+		{value: Bad{}, want: `vtag: field vtag_test.Bad.Name: vrule: "unknown": unknown rule "unknown"`},
+	}
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if got := recover(); got != c.want {
+					t.Errorf("Got (%v) != Want (%s)", got, c.want)
+				}
+			}()
+			vtag.Schema(c.value)
+		}()
+	}
+}
+
+func TestSchema_Options(t *testing.T) {
+	errs := v.Validate(vtag.Schema(Person{}), v.WithMaxErrors(1))
+	if len(errs) != 1 {
+		t.Errorf("Got (%v) != Want (1 error)", errs)
+	}
+}
+
+type Stock struct {
+	Levels map[int]int `validate:"dive,gte(1)"`
+}
+
+func TestValidate_MapOrder(t *testing.T) {
+	value := Stock{Levels: map[int]int{10: 0, 2: 0, 1: 1, 30: 0}}
+	want := []string{"Levels[2]", "Levels[10]", "Levels[30]"}
+
+	// Run several times, since the order of map iteration is random.
+	for i := 0; i < 10; i++ {
+		var got []string
+		for _, err := range vtag.Validate(value) {
+			got = append(got, err.Field())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Got (%+v) != Want (%+v)", got, want)
+		}
+	}
+}
+
+type Doc struct {
+	*Base
+	Title string `json:"title" validate:"required"`
+}
+
+func TestValidate_EmbeddedPointer(t *testing.T) {
+	cases := []struct {
+		name  string
+		value Doc
+		want  []string
+	}{
+		{
+			name:  "nil",
+			value: Doc{Title: "x"},
+			want:  []string{"id"},
+		},
+		{
+			name:  "non-nil",
+			value: Doc{Base: &Base{ID: 0}},
+			want:  []string{"id", "title"},
+		},
+		{
+			name:  "valid",
+			value: Doc{Base: &Base{ID: 1}, Title: "x"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			for _, err := range vtag.Validate(c.value) {
+				got = append(got, err.Field())
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Got (%+v) != Want (%+v)", got, c.want)
+			}
+		})
+	}
+}